@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cacheCmd      = "cache"
+	cacheCmdShort = "Manage the note metadata cache"
+	cacheCmdDesc  = `Manage the cache of note metadata used when listing notes.
+The cache is kept under ~/.note-app/cache/ and remembers each note's front
+matter so unchanged notes aren't re-read on every run. A note is read again
+whenever its size or modification time changes, so the cache never needs
+clearing after editing notes outside the app; 'cache clear' is there if it
+ever gets out of step.`
+)
+
+func init() {
+	newCacheCommand := NewCacheCommand()
+	root.RootCmd.AddCommand(newCacheCommand)
+}
+
+// NewCacheCommand creates the cache command and its clear subcommand.
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cacheCmd,
+		Short: cacheCmdShort,
+		Long:  cacheCmdDesc,
+	}
+
+	cmd.AddCommand(newClearCommand())
+	return cmd
+}
+
+func newClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Delete the metadata cache for every notes directory",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start("Clearing metadata cache")
+
+			if err := file.ClearCache(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.End("Metadata cache cleared")
+			fmt.Println("Metadata cache cleared; it will be rebuilt the next time notes are listed.")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	appconfig "github.com/rhysmah/note-app/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	configCmd      = "config"
+	configCmdShort = "Read and write persistent settings"
+	configCmdDesc  = `Read and write persistent settings stored in ~/.note-app/config.
+Settings apply to every command unless overridden by a flag.
+Example: note-app config set list.sort_by mod`
+)
+
+func init() {
+	newConfigCommand := NewConfigCommand()
+	root.RootCmd.AddCommand(newConfigCommand)
+}
+
+// NewConfigCommand creates the config command and its get, set, unset and
+// list subcommands.
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   configCmd,
+		Short: configCmdShort,
+		Long:  configCmdDesc,
+	}
+
+	cmd.AddCommand(
+		newGetCommand(),
+		newSetCommand(),
+		newUnsetCommand(),
+		newListCommand(),
+	)
+	return cmd
+}
+
+func newGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Print the value of a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := root.AppConfig.Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+func newSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Change the value of a setting",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+			root.AppLogger.Start(fmt.Sprintf("Setting config %q to %q", key, value))
+
+			if err := root.AppConfig.Set(key, value); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to set %q: %v", key, err))
+				return err
+			}
+			if err := root.AppConfig.Save(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.Success(fmt.Sprintf("Config %q set", key))
+			fmt.Printf("%s = %s\n", key, value)
+			return nil
+		},
+	}
+}
+
+func newUnsetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset [key]",
+		Short: "Restore a setting to its default value",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			root.AppLogger.Start(fmt.Sprintf("Unsetting config %q", key))
+
+			if err := root.AppConfig.Unset(key); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to unset %q: %v", key, err))
+				return err
+			}
+			if err := root.AppConfig.Save(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.Success(fmt.Sprintf("Config %q unset", key))
+			fmt.Printf("%s restored to default\n", key)
+			return nil
+		},
+	}
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every setting and its current value",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, key := range appconfig.Keys() {
+				value, err := root.AppConfig.Get(key)
+				if err != nil {
+					return err
+				}
+
+				annotation := ""
+				if !root.AppConfig.IsSet(key) {
+					annotation = "  (default)"
+				}
+				if key == appconfig.KeyNotesDir {
+					annotation = fmt.Sprintf("  (using %s from %s)",
+						root.DirManager.NotesDir(), root.DirManager.NotesDirSource())
+				}
+
+				fmt.Printf("%s = %q%s\n", key, value, annotation)
+				fmt.Printf("    %s\n", appconfig.Description(key))
+			}
+			return nil
+		},
+	}
+}
@@ -1,21 +1,50 @@
 package delete
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/terminal"
+	"github.com/rhysmah/note-app/internal/trash"
 	"github.com/spf13/cobra"
 )
 
 const (
 	delCmd      = "del"
-	delCmdShort = "Delete a note"
-	delCmdDesc  = `Delete a note with a specified name.
-	Usage: note-app del [note-id] or note-app d [note-id]`
+	delCmdShort = "Delete one or more notes"
+	delCmdDesc  = `Delete one or more notes.
+Each note can be given by its full file name, the name it was created with,
+a unique prefix of its ID, or its number in 'list'. Notes can also be
+selected with --match, --before and --tag; when several selectors are given,
+a note must satisfy all of them.
+Deleted notes are moved to the trash and can be brought back with 'restore';
+use --permanent to remove them for good.
+With --pick, the chosen notes (or every note, if none are chosen) are shown
+as a numbered list to pick from, such as "1 3 5-7"; this needs a terminal.
+Use --dry-run to see which notes would be deleted without deleting them.
+When stdin is not a terminal, --yes is required to confirm deletion.
+Usage: note-app del [note]... [--match 'standup_*'] [--before 2026-01-01] [--tag scratch] [--pick]`
+
+	permanentFlag = "permanent"
+
+	yesFlag      = "yes"
+	yesFlagShort = "y"
+
+	dryRunFlag = "dry-run"
+
+	matchFlag  = "match"
+	beforeFlag = "before"
+	tagFlag    = "tag"
+
+	pickFlag      = "pick"
+	pickFlagShort = "p"
 )
 
 func init() {
@@ -27,68 +56,263 @@ func NewDeleteCommand() *cobra.Command {
 	deleteCmd := &DeleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   delCmd,
+		Use:   delCmd + " [note]...",
 		Short: delCmdShort,
 		Long:  delCmdDesc,
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			root.AppLogger.Start(fmt.Sprintf("Deleting note %q", args[0]))
+			root.AppLogger.Start(fmt.Sprintf("Deleting notes %q", args))
 
 			// NotesDir identified in PersistentPreRun check in root.go
 			deleteCmd.notesDir = root.DirManager.NotesDir()
-			deleteCmd.noteName = args[0]
+			deleteCmd.noteNames = args
+			deleteCmd.in = os.Stdin
+			deleteCmd.interactive = terminal.IsTerminal(os.Stdin)
 
-			if err := deleteNote(deleteCmd); err != nil {
-				errMsg := fmt.Sprintf("Failed to delete note %q", deleteCmd.noteName)
+			if err := deleteNotes(deleteCmd); err != nil {
+				errMsg := fmt.Sprintf("Failed to delete notes: %v", err)
 				root.AppLogger.Fail(errMsg)
 				return errors.New(errMsg)
 			}
 			return nil
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&deleteCmd.permanent, permanentFlag, false, "Delete notes permanently instead of moving them to the trash")
+	flags.BoolVarP(&deleteCmd.yes, yesFlag, yesFlagShort, false, "Delete without asking for confirmation")
+	flags.BoolVar(&deleteCmd.dryRun, dryRunFlag, false, "Show which notes would be deleted without deleting them")
+	flags.StringVar(&deleteCmd.match, matchFlag, "", "Select notes whose name matches a glob pattern")
+	flags.StringVar(&deleteCmd.before, beforeFlag, "", "Select notes created before a date (YYYY-MM-DD)")
+	flags.StringArrayVar(&deleteCmd.tags, tagFlag, nil, "Select notes with this tag (repeatable)")
+	flags.BoolVarP(&deleteCmd.pick, pickFlag, pickFlagShort, false, "Pick the notes to delete from a numbered list")
+
 	return cmd
 }
 
-func deleteNote(opts *DeleteOptions) error {
-	notePath := filepath.Join(opts.notesDir, opts.noteName)
+func deleteNotes(opts *DeleteOptions) error {
+	if err := NewValidator().Run(opts); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	files, err := file.PrepareNoteFiles(root.AppLogger, opts.notesDir)
+	if err != nil {
+		return err
+	}
+
+	notes, err := selectNotes(opts, files)
+	if err != nil {
+		return err
+	}
+
+	if len(notes) == 0 {
+		fmt.Println("No notes matched; nothing to delete")
+		root.AppLogger.Info("No notes matched the delete selectors")
+		return nil
+	}
+
+	// The pick list and the confirmation share a reader so that answers
+	// typed ahead aren't lost between the two prompts.
+	in := bufio.NewReader(opts.in)
 
-	if _, err := os.Stat(notePath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("note does not exist %v", err)
+	if opts.pick {
+		notes, err = pickNotes(in, os.Stdout, notes)
+		if err != nil {
+			return err
+		}
+		if len(notes) == 0 {
+			fmt.Println("No notes picked; nothing to delete")
+			root.AppLogger.Info("No notes picked from the pick list")
+			return nil
 		}
-		return fmt.Errorf("failed to access note: %w", err)
 	}
 
-	if !confirmDeletion(opts.noteName) {
-		fmt.Println("User cancelled delete operation")
+	if opts.dryRun {
+		fmt.Printf("Dry run: the following %d notes would be %s:\n", len(notes), deletionVerb(opts))
+		printNotes(notes)
+		root.AppLogger.Info(fmt.Sprintf("Dry run matched %d notes; nothing deleted", len(notes)))
 		return nil
 	}
 
-	root.AppLogger.Info(fmt.Sprintf("Deleting note %q...", opts.noteName))
+	fmt.Printf("The following %d notes will be %s:\n", len(notes), deletionVerb(opts))
+	printNotes(notes)
+
+	if !opts.yes {
+		if !opts.interactive {
+			return fmt.Errorf("refusing to prompt for confirmation because stdin is not a terminal; re-run with --%s to confirm", yesFlag)
+		}
+
+		confirmed, err := confirmDeletion(in, os.Stdout, len(notes))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("User cancelled delete operation")
+			return nil
+		}
+	}
+
+	results := make([]deleteResult, 0, len(notes))
+	for _, note := range notes {
+		results = append(results, deleteResult{note: note, err: deleteNote(opts, note)})
+	}
+
+	recordDeletions(results)
+	return reportResults(results)
+}
+
+// recordDeletions commits the notes that were successfully deleted to the
+// git history, if enabled.
+func recordDeletions(results []deleteResult) {
+	var deleted, paths []string
+	for _, result := range results {
+		if result.err == nil {
+			deleted = append(deleted, result.note.Name)
+			paths = append(paths, result.note.FilePath)
+		}
+	}
+	if len(deleted) == 0 {
+		return
+	}
+	root.RecordChange(fmt.Sprintf("Delete %s", strings.Join(deleted, ", ")), paths...)
+}
+
+// selectNotes resolves the named notes and applies the selectors, returning
+// every chosen note once, in listing order. With --pick and nothing else,
+// every note is a candidate.
+func selectNotes(opts *DeleteOptions, files []file.File) ([]file.File, error) {
+	selected := make(map[string]bool)
 
-	if err := os.Remove(notePath); err != nil {
-		return fmt.Errorf("failed to delete note file: %w", err)
+	var unresolved []string
+	for _, name := range opts.noteNames {
+		note, err := file.ResolveNote(files, name)
+		if err != nil {
+			unresolved = append(unresolved, err.Error())
+			continue
+		}
+		selected[note.FilePath] = true
+	}
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("could not resolve notes:\n  %s", strings.Join(unresolved, "\n  "))
 	}
 
-	fmt.Printf("Successfully deleted %q", opts.noteName)
-	root.AppLogger.Success(fmt.Sprintf("Note %q successfully deleted", opts.noteName))
+	if hasSelectors(opts) || (opts.pick && len(opts.noteNames) == 0) {
+		for _, f := range files {
+			if matchesSelectors(opts, f) {
+				selected[f.FilePath] = true
+			}
+		}
+	}
+
+	notes := make([]file.File, 0, len(selected))
+	for _, f := range files {
+		if selected[f.FilePath] {
+			notes = append(notes, f)
+		}
+	}
+	return notes, nil
+}
+
+func hasSelectors(opts *DeleteOptions) bool {
+	return opts.match != "" || !opts.beforeTime.IsZero() || len(opts.tags) > 0
+}
+
+// matchesSelectors reports whether a note satisfies every selector given.
+func matchesSelectors(opts *DeleteOptions, f file.File) bool {
+	if opts.match != "" {
+		fullMatch, _ := filepath.Match(opts.match, f.Name)
+		displayMatch, _ := filepath.Match(opts.match, file.DisplayName(f.Name))
+		if !fullMatch && !displayMatch {
+			return false
+		}
+	}
+
+	if !opts.beforeTime.IsZero() && !f.DateCreated.Before(opts.beforeTime) {
+		return false
+	}
+
+	return f.HasTags(opts.tags...)
+}
+
+func deleteNote(opts *DeleteOptions, note file.File) error {
+	root.AppLogger.Info(fmt.Sprintf("Deleting note %q...", note.Name))
+
+	if opts.permanent {
+		if err := os.Remove(note.FilePath); err != nil {
+			root.AppLogger.Fail(fmt.Sprintf("Failed to delete %q: %v", note.Name, err))
+			return fmt.Errorf("failed to delete note file: %w", err)
+		}
+	} else {
+		bin := trash.New(root.DirManager.TrashDir())
+		if _, err := bin.Move(note.FilePath); err != nil {
+			root.AppLogger.Fail(fmt.Sprintf("Failed to trash %q: %v", note.Name, err))
+			return err
+		}
+	}
+
+	root.AppLogger.Success(fmt.Sprintf("Note %q successfully deleted", note.Name))
 	return nil
 }
 
-func confirmDeletion(noteName string) bool {
+// reportResults prints the outcome for every note and returns an error if
+// any deletion failed.
+func reportResults(results []deleteResult) error {
+	failed := 0
+
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			fmt.Printf("  FAILED   %s: %v\n", result.note.Name, result.err)
+			continue
+		}
+		fmt.Printf("  deleted  %s\n", result.note.Name)
+	}
+
+	fmt.Printf("%d deleted, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notes could not be deleted", failed, len(results))
+	}
+	return nil
+}
+
+func deletionVerb(opts *DeleteOptions) string {
+	if opts.permanent {
+		return "permanently deleted"
+	}
+	return "moved to the trash"
+}
+
+func printNotes(notes []file.File) {
+	for _, note := range notes {
+		fmt.Printf("  %s\n", note.Name)
+	}
+}
+
+// confirmDeletion asks the user to confirm on out and reads the answer from
+// in, re-prompting on invalid answers. It returns an error if in runs out
+// before a valid answer is given.
+func confirmDeletion(in io.Reader, out io.Writer, count int) (bool, error) {
+	scanner := bufio.NewScanner(in)
+
 	for {
-		var response string
+		fmt.Fprintf(out, "Are you sure you want to delete %d notes? (y/n): ", count)
 
-		fmt.Printf("Are you sure you want to delete %q? (y/n): ", noteName)
-		fmt.Scanf("%s", &response)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			if err := scanner.Err(); err != nil {
+				return false, fmt.Errorf("failed to read confirmation: %w", err)
+			}
+			return false, fmt.Errorf("no confirmation received; re-run with --%s to delete without prompting", yesFlag)
+		}
 
-		switch strings.ToLower(response) {
-		case "y":
-			return true
-		case "n":
-			return false
+		switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
 		default:
-			fmt.Println("Invalid response. Enter (y)es or (n)o.")
+			fmt.Fprintln(out, "Invalid response. Enter (y)es or (n)o.")
 		}
 	}
 }
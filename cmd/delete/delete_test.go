@@ -0,0 +1,108 @@
+package delete
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+func TestConfirmDeletion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    bool
+		prompts int
+	}{
+		{name: "y", input: "y\n", want: true, prompts: 1},
+		{name: "yes", input: "yes\n", want: true, prompts: 1},
+		{name: "upper case with spaces", input: "  YES \n", want: true, prompts: 1},
+		{name: "n", input: "n\n", want: false, prompts: 1},
+		{name: "no", input: "no\n", want: false, prompts: 1},
+		{name: "no trailing newline", input: "y", want: true, prompts: 1},
+		{name: "reprompts on invalid input", input: "maybe\n\ny\n", want: true, prompts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			got, err := confirmDeletion(strings.NewReader(tt.input), &out, 2)
+			if err != nil {
+				t.Fatalf("confirmDeletion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("confirmDeletion() = %v, want %v", got, tt.want)
+			}
+
+			prompts := strings.Count(out.String(), "delete 2 notes? (y/n)")
+			if prompts != tt.prompts {
+				t.Errorf("prompted %d times, want %d; output:\n%s", prompts, tt.prompts, out.String())
+			}
+			if invalid := strings.Count(out.String(), "Invalid response"); invalid != tt.prompts-1 {
+				t.Errorf("reported %d invalid responses, want %d", invalid, tt.prompts-1)
+			}
+		})
+	}
+}
+
+func TestConfirmDeletionEOF(t *testing.T) {
+	for _, input := range []string{"", "maybe\n", "what\nhuh\n"} {
+		var out strings.Builder
+
+		got, err := confirmDeletion(strings.NewReader(input), &out, 1)
+		if err == nil {
+			t.Fatalf("confirmDeletion(%q) returned no error at end of input", input)
+		}
+		if got {
+			t.Errorf("confirmDeletion(%q) = true at end of input, want false", input)
+		}
+		if !strings.Contains(err.Error(), "--"+yesFlag) {
+			t.Errorf("error %q doesn't suggest --%s", err, yesFlag)
+		}
+	}
+}
+
+func TestPickNotes(t *testing.T) {
+	notes := []file.File{{Name: "a.txt"}, {Name: "b.txt"}, {Name: "c.txt"}, {Name: "d.txt"}}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "single", input: "2\n", want: []string{"b.txt"}},
+		{name: "list and range", input: "4, 1-2\n", want: []string{"a.txt", "b.txt", "d.txt"}},
+		{name: "all", input: "all\n", want: []string{"a.txt", "b.txt", "c.txt", "d.txt"}},
+		{name: "empty cancels", input: "\n", want: []string{}},
+		{name: "reprompts on invalid input", input: "5\n3-1\nx\n3\n", want: []string{"c.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			picked, err := pickNotes(bufio.NewReader(strings.NewReader(tt.input)), &out, notes)
+			if err != nil {
+				t.Fatalf("pickNotes() error = %v", err)
+			}
+
+			got := make([]string, len(picked))
+			for i, note := range picked {
+				got[i] = note.Name
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("pickNotes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickNotesEOF(t *testing.T) {
+	var out strings.Builder
+
+	if _, err := pickNotes(bufio.NewReader(strings.NewReader("9\n")), &out, []file.File{{Name: "a.txt"}}); err == nil {
+		t.Fatal("pickNotes() returned no error at end of input")
+	}
+}
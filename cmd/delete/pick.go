@@ -0,0 +1,105 @@
+package delete
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+// pickAll selects every note in the pick list.
+const pickAll = "all"
+
+// pickNotes prints notes as a numbered list on out and reads which to keep
+// selected from in, as numbers and ranges such as "1 3 5-7", or "all".
+// An empty answer picks nothing. It re-prompts on invalid answers and
+// returns an error if in runs out before a valid answer is given.
+func pickNotes(in *bufio.Reader, out io.Writer, notes []file.File) ([]file.File, error) {
+	width := len(strconv.Itoa(len(notes)))
+	for i, note := range notes {
+		fmt.Fprintf(out, "  %*d) %s\n", width, i+1, note.RelPath())
+	}
+
+	for {
+		fmt.Fprintf(out, "Notes to delete (e.g. 1 3 5-7, %s; empty to cancel): ", pickAll)
+
+		line, err := in.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			fmt.Fprintln(out)
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("no notes picked; re-run and enter the numbers of the notes to delete")
+			}
+			return nil, fmt.Errorf("failed to read picked notes: %w", err)
+		}
+
+		picked, err := parsePicks(line, len(notes))
+		if err != nil {
+			fmt.Fprintf(out, "Invalid response: %v\n", err)
+			continue
+		}
+
+		chosen := make([]file.File, 0, len(notes))
+		for i, note := range notes {
+			if picked[i] {
+				chosen = append(chosen, note)
+			}
+		}
+		return chosen, nil
+	}
+}
+
+// parsePicks parses an answer to the pick prompt into the set of chosen
+// 0-based positions in a list of count notes.
+func parsePicks(answer string, count int) (map[int]bool, error) {
+	picked := make(map[int]bool)
+
+	fields := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+	for _, field := range fields {
+		if strings.EqualFold(field, pickAll) {
+			for i := range count {
+				picked[i] = true
+			}
+			continue
+		}
+
+		first, last, isRange := strings.Cut(field, "-")
+		if !isRange {
+			last = first
+		}
+
+		from, err := pickNumber(first, count)
+		if err != nil {
+			return nil, err
+		}
+		to, err := pickNumber(last, count)
+		if err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, fmt.Errorf("range %q runs backwards", field)
+		}
+
+		for i := from; i <= to; i++ {
+			picked[i-1] = true
+		}
+	}
+	return picked, nil
+}
+
+// pickNumber parses a 1-based position in a list of count notes.
+func pickNumber(s string, count int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < 1 || n > count {
+		return 0, fmt.Errorf("%d is not between 1 and %d", n, count)
+	}
+	return n, nil
+}
@@ -1,6 +1,35 @@
 package delete
 
+import (
+	"io"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+)
+
 type DeleteOptions struct {
-	noteName string
-	notesDir string
+	noteNames []string
+	notesDir  string
+	permanent bool
+	yes       bool
+	dryRun    bool
+	pick      bool
+
+	// in is where the pick list and confirmation prompt read answers from;
+	// interactive reports whether it is attached to a terminal.
+	in          io.Reader
+	interactive bool
+
+	// Selectors pick notes in addition to those named as arguments.
+	match  string
+	before string
+	tags   []string
+
+	beforeTime time.Time
+}
+
+// deleteResult records the outcome of deleting a single note.
+type deleteResult struct {
+	note file.File
+	err  error
 }
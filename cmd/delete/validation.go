@@ -0,0 +1,76 @@
+package delete
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/timeutil"
+	"github.com/rhysmah/note-app/validator"
+)
+
+// NewValidator creates a validator with a predefined set of validation rules.
+func NewValidator() *validator.Validator[DeleteOptions] {
+	return &validator.Validator[DeleteOptions]{
+		Rules: []validator.ValidationRule[DeleteOptions]{
+			validateSelectionExists,
+			validatePick,
+			validateMatchPattern,
+			validateBeforeDate,
+			validateTags,
+		},
+	}
+}
+
+// validateSelectionExists checks that at least one note or selector was
+// given, or that notes are to be picked from a list.
+func validateSelectionExists(opts *DeleteOptions) error {
+	if len(opts.noteNames) == 0 && opts.match == "" && opts.before == "" && len(opts.tags) == 0 && !opts.pick {
+		return fmt.Errorf("specify at least one note, select notes with --%s, --%s or --%s, or use --%s",
+			matchFlag, beforeFlag, tagFlag, pickFlag)
+	}
+	return nil
+}
+
+// validatePick checks that --pick can prompt on a terminal.
+func validatePick(opts *DeleteOptions) error {
+	if opts.pick && !opts.interactive {
+		return fmt.Errorf("--%s needs a terminal to pick notes from", pickFlag)
+	}
+	return nil
+}
+
+// validateMatchPattern checks that the --match glob is well formed.
+func validateMatchPattern(opts *DeleteOptions) error {
+	if opts.match == "" {
+		return nil
+	}
+	if _, err := filepath.Match(opts.match, ""); err != nil {
+		return fmt.Errorf("invalid --%s pattern %q: %w", matchFlag, opts.match, err)
+	}
+	return nil
+}
+
+// validateBeforeDate parses the --before date.
+func validateBeforeDate(opts *DeleteOptions) error {
+	if opts.before == "" {
+		return nil
+	}
+
+	before, err := timeutil.ParseDate(opts.before)
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", beforeFlag, err)
+	}
+	opts.beforeTime = before
+	return nil
+}
+
+// validateTags normalizes the --tag values so they match stored tags.
+func validateTags(opts *DeleteOptions) error {
+	tags, err := file.NormalizeTags(opts.tags)
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", tagFlag, err)
+	}
+	opts.tags = tags
+	return nil
+}
@@ -0,0 +1,111 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/textdiff"
+	"github.com/rhysmah/note-app/internal/versions"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffCmd      = "diff"
+	diffCmdShort = "Compare two versions of a note"
+	diffCmdDesc  = `Show a unified diff between two saved versions of a note.
+Versions are given by number (3 or v3) or hash prefix, as shown by 'versions'.
+Leave out the second version to compare against the note as it is now.
+Example: note-app diff meeting v2 v5`
+)
+
+func init() {
+	newDiffCommand := NewDiffCommand()
+	root.RootCmd.AddCommand(newDiffCommand)
+}
+
+func NewDiffCommand() *cobra.Command {
+	diffCmdOpts := &DiffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   diffCmd + " [note] [version] [version]",
+		Short: diffCmdShort,
+		Long:  diffCmdDesc,
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Comparing versions of note %q", args[0]))
+
+			diffCmdOpts.notesDir = root.DirManager.NotesDir()
+			diffCmdOpts.noteName = args[0]
+			diffCmdOpts.from = args[1]
+			if len(args) == 3 {
+				diffCmdOpts.to = args[2]
+			}
+
+			if err := diffVersions(diffCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to compare versions of %q: %v", diffCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Versions compared successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func diffVersions(opts *DiffOptions) error {
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	store := versions.New(root.DirManager.VersionsDir())
+	noteVersions, err := store.List(note.ID)
+	if err != nil {
+		return err
+	}
+
+	fromLabel, fromContent, err := versionContent(store, noteVersions, opts.from)
+	if err != nil {
+		return err
+	}
+
+	toLabel := "current"
+	var toContent []byte
+	if opts.to == "" {
+		if toContent, err = os.ReadFile(note.FilePath); err != nil {
+			return fmt.Errorf("failed to read note: %w", err)
+		}
+	} else if toLabel, toContent, err = versionContent(store, noteVersions, opts.to); err != nil {
+		return err
+	}
+
+	diff := textdiff.Unified(
+		fmt.Sprintf("%s (%s)", note.Name, fromLabel),
+		fmt.Sprintf("%s (%s)", note.Name, toLabel),
+		string(fromContent), string(toContent), textdiff.DefaultContext,
+	)
+	if diff == "" {
+		fmt.Printf("No differences between %s and %s\n", fromLabel, toLabel)
+		return nil
+	}
+
+	fmt.Print(diff)
+	return nil
+}
+
+// versionContent resolves query to a saved version and returns its label and content.
+func versionContent(store *versions.Store, noteVersions []versions.Version, query string) (string, []byte, error) {
+	version, err := versions.Resolve(noteVersions, query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	content, err := store.Content(version)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("v%d", version.Number), content, nil
+}
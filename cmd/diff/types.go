@@ -0,0 +1,8 @@
+package diff
+
+type DiffOptions struct {
+	noteName string
+	from     string
+	to       string // empty compares against the note's current content
+	notesDir string
+}
@@ -0,0 +1,109 @@
+package edit
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/editor"
+	"github.com/spf13/cobra"
+)
+
+const (
+	editCmd      = "edit"
+	editCmdShort = "Edit a note in your text editor"
+	editCmdDesc  = `Open a note in your text editor.
+The editor is taken from $VISUAL, then $EDITOR, falling back to vi (notepad on Windows).
+The note can be given by its full file name, the name it was created with,
+a unique prefix of its ID, or its number in 'list'.
+Example: note-app edit meeting`
+)
+
+func init() {
+	newEditCommand := NewEditCommand()
+	root.RootCmd.AddCommand(newEditCommand)
+}
+
+func NewEditCommand() *cobra.Command {
+	editCmdOpts := &EditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   editCmd + " [note]",
+		Short: editCmdShort,
+		Long:  editCmdDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Editing note %q", args[0]))
+
+			editCmdOpts.notesDir = root.DirManager.NotesDir()
+			editCmdOpts.noteName = args[0]
+
+			if err := editNote(editCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to edit note %q: %v", editCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Note edit completed successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func editNote(opts *EditOptions) error {
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	contentBefore, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+	hashBefore := sha256.Sum256(contentBefore)
+
+	root.AppLogger.Info(fmt.Sprintf("Opening %q in %v", note.Name, editor.Command()))
+	if err := editor.Open(note.FilePath); err != nil {
+		return err
+	}
+
+	contentAfter, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+	hashAfter := sha256.Sum256(contentAfter)
+
+	if hashBefore == hashAfter {
+		// Some editors rewrite the file on exit even when nothing changed;
+		// put the modification time back so sorting by date stays accurate.
+		if err := os.Chtimes(note.FilePath, time.Now(), note.DateModified); err != nil {
+			return fmt.Errorf("failed to restore modification time: %w", err)
+		}
+
+		root.AppLogger.Info(fmt.Sprintf("No changes made to %q", note.Name))
+		fmt.Printf("No changes made to %s\n", note.Name)
+		return nil
+	}
+
+	// A note without an ID of its own gets one before its versions, which
+	// are kept by ID, are saved.
+	if err := file.AssignID(note); err != nil {
+		return err
+	}
+	if contentAfter, err = os.ReadFile(note.FilePath); err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+	hashAfter = sha256.Sum256(contentAfter)
+
+	root.AppLogger.Success(fmt.Sprintf("Note %q updated (sha256 %x -> %x)", note.Name, hashBefore, hashAfter))
+	fmt.Printf("Updated note: %s\n", note.Name)
+
+	// Snapshot the previous content too, in case it predates versioning.
+	root.SnapshotNote(note, contentBefore)
+	root.SnapshotNote(note, contentAfter)
+	root.RecordChange(fmt.Sprintf("Edit %s", note.Name), note.FilePath)
+	return nil
+}
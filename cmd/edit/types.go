@@ -0,0 +1,6 @@
+package edit
+
+type EditOptions struct {
+	noteName string
+	notesDir string
+}
@@ -0,0 +1,82 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/spf13/cobra"
+)
+
+const (
+	historyCmd      = "history"
+	historyCmdShort = "Show the past versions of a note"
+	historyCmdDesc  = `List every recorded version of a note, newest first.
+Versions are recorded in a git repository in the notes directory when the
+history.git setting is enabled ('note-app config set history.git true').
+Use 'revert' with a revision from this list to bring an old version back.
+The note can be given by its full file name, the name it was created with,
+a unique prefix of its ID, or its number in 'list'.
+Example: note-app history meeting`
+
+	historyTimeFormat = "2006-01-02 15:04"
+)
+
+func init() {
+	newHistoryCommand := NewHistoryCommand()
+	root.RootCmd.AddCommand(newHistoryCommand)
+}
+
+func NewHistoryCommand() *cobra.Command {
+	historyCmdOpts := &HistoryOptions{}
+
+	cmd := &cobra.Command{
+		Use:   historyCmd + " [note]",
+		Short: historyCmdShort,
+		Long:  historyCmdDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Showing history of note %q", args[0]))
+
+			historyCmdOpts.notesDir = root.DirManager.NotesDir()
+			historyCmdOpts.noteName = args[0]
+
+			if err := showHistory(historyCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to show history of %q: %v", historyCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("History shown successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func showHistory(opts *HistoryOptions) error {
+	repo, err := root.OpenHistoryRepo()
+	if err != nil {
+		return err
+	}
+
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := root.NoteRevisions(repo, note)
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) == 0 {
+		fmt.Printf("No recorded history for %s\n", note.Name)
+		return nil
+	}
+
+	fmt.Printf("History of %s:\n", note.Name)
+	for _, rev := range revisions {
+		fmt.Printf("  %s  %s  %s\n", rev.ShortHash(), rev.Date.Local().Format(historyTimeFormat), rev.Subject)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+package history
+
+type HistoryOptions struct {
+	noteName string
+	notesDir string
+}
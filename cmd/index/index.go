@@ -0,0 +1,93 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	searchindex "github.com/rhysmah/note-app/internal/index"
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexCmd      = "index"
+	indexCmdShort = "Manage the search index"
+	indexCmdDesc  = `Manage the persistent search index used by 'search --ranked'.
+The index is kept under ~/.note-app/index/ and is updated automatically
+before each ranked search; use 'index rebuild' to recreate it from scratch.`
+
+	indexDateFormat = "2006-01-02 15:04"
+)
+
+func init() {
+	newIndexCommand := NewIndexCommand()
+	root.RootCmd.AddCommand(newIndexCommand)
+}
+
+// NewIndexCommand creates the index command and its rebuild and status subcommands.
+func NewIndexCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   indexCmd,
+		Short: indexCmdShort,
+		Long:  indexCmdDesc,
+	}
+
+	cmd.AddCommand(newRebuildCommand(), newStatusCommand())
+	return cmd
+}
+
+func newRebuildCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild",
+		Short: "Discard the search index and rebuild it from every note",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start("Rebuilding search index")
+			notesDir := root.DirManager.NotesDir()
+
+			files, err := file.PrepareNoteFiles(root.AppLogger, notesDir)
+			if err != nil {
+				return fmt.Errorf("failed to get files: %w", err)
+			}
+
+			idx := searchindex.New(root.DirManager.IndexDir(), notesDir)
+			stats, err := idx.Update(root.AppLogger, files)
+			if err != nil {
+				return fmt.Errorf("failed to build index: %w", err)
+			}
+
+			if err := idx.Save(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.End("Search index rebuilt")
+			fmt.Printf("Indexed %d notes (%d distinct terms)\n", stats.Added, idx.TermCount())
+			return nil
+		},
+	}
+}
+
+func newStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show what the search index contains",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			idx, err := searchindex.Load(root.AppLogger, root.DirManager.IndexDir(), root.DirManager.NotesDir())
+			if err != nil {
+				return err
+			}
+
+			if idx.UpdatedAt.IsZero() {
+				fmt.Println("The search index has not been built yet. Run 'note-app index rebuild'.")
+				return nil
+			}
+
+			fmt.Printf("Notes indexed:  %d\n", len(idx.Docs))
+			fmt.Printf("Distinct terms: %d\n", idx.TermCount())
+			fmt.Printf("Last updated:   %s\n", idx.UpdatedAt.Format(indexDateFormat))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package list
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+// sizeUnits maps the suffixes accepted by parseSize to their size in bytes.
+// Sizes are binary, matching how the table shows them.
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"kb", 1 << 10},
+	{"mb", 1 << 20},
+	{"gb", 1 << 30},
+	{"k", 1 << 10},
+	{"m", 1 << 20},
+	{"g", 1 << 30},
+	{"b", 1},
+}
+
+// parseSize parses a size such as "512", "2KB" or "1.5m" into bytes.
+func parseSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	multiplier := int64(1)
+
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q: use a number of bytes, optionally followed by KB, MB or GB", s)
+	}
+	return int64(number * float64(multiplier)), nil
+}
+
+// filterFiles returns only the files matching every filter given.
+// If no filters are given, all files are returned.
+func (opts *ListOptions) filterFiles(files []file.File) []file.File {
+	filtered := make([]file.File, 0, len(files))
+	for _, f := range files {
+		if opts.matches(f) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// matches reports whether a note passes the tag, date, size and name filters.
+func (opts *ListOptions) matches(f file.File) bool {
+	if !f.HasTags(opts.Tags...) {
+		return false
+	}
+
+	if !opts.since.IsZero() && f.DateModified.Before(opts.since) {
+		return false
+	}
+	if !opts.until.IsZero() && !f.DateModified.Before(opts.until) {
+		return false
+	}
+
+	if !opts.createdFrom.IsZero() &&
+		(f.DateCreated.Before(opts.createdFrom) || !f.DateCreated.Before(opts.createdTo)) {
+		return false
+	}
+
+	if f.Size < opts.minSize || (opts.hasMaxSize && f.Size > opts.maxSize) {
+		return false
+	}
+
+	if opts.NameGlob != "" {
+		nameMatch, _ := path.Match(opts.NameGlob, f.Name)
+		displayMatch, _ := path.Match(opts.NameGlob, file.DisplayName(f.Name))
+		pathMatch, _ := path.Match(opts.NameGlob, f.RelPath())
+		if !nameMatch && !displayMatch && !pathMatch {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filtered reports whether any filter narrows the notes listed. --limit
+// isn't a filter: it only shortens what is displayed.
+func (opts *ListOptions) filtered() bool {
+	return len(opts.Tags) > 0 || !opts.since.IsZero() || !opts.until.IsZero() ||
+		!opts.createdFrom.IsZero() || opts.minSize > 0 || opts.hasMaxSize ||
+		opts.NameGlob != ""
+}
+
+// summarizesFolders reports whether notes inside folders are shown only as a
+// count per folder, as in the default table.
+func (opts *ListOptions) summarizesFolders() bool {
+	return !opts.Output.Structured() && opts.template == nil && !opts.Tree && !opts.Recursive
+}
+
+// limitFiles keeps the first opts.Limit files, if a limit was given.
+func (opts *ListOptions) limitFiles(files []file.File) []file.File {
+	if opts.Limit > 0 && len(files) > opts.Limit {
+		return files[:opts.Limit]
+	}
+	return files
+}
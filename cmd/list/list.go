@@ -1,13 +1,22 @@
 package list
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/rhysmah/note-app/cmd/root"
 	"github.com/rhysmah/note-app/file"
 	"github.com/rhysmah/note-app/internal/filesystem"
 	"github.com/rhysmah/note-app/internal/logger"
+	"github.com/rhysmah/note-app/internal/notebook"
+	"github.com/rhysmah/note-app/internal/output"
+	"github.com/rhysmah/note-app/internal/terminal"
 	"github.com/spf13/cobra"
 )
 
@@ -18,9 +27,62 @@ const (
 	orderCmd      = "order"
 	orderCmdShort = "o"
 
+	tagCmd      = "tag"
+	tagCmdShort = "t"
+
+	allNotebooksCmd = "all-notebooks"
+
+	recursiveCmd      = "recursive"
+	recursiveCmdShort = "r"
+
+	treeCmd = "tree"
+
+	strictCmd = "strict"
+
+	columnsCmd = "columns"
+
+	absoluteTimeCmd = "absolute-time"
+
+	formatCmd = "format"
+
+	sinceCmd     = "since"
+	untilCmd     = "until"
+	createdOnCmd = "created-on"
+	minSizeCmd   = "min-size"
+	maxSizeCmd   = "max-size"
+	nameGlobCmd  = "name-glob"
+	limitCmd     = "limit"
+
+	// noColorEnvVar disables colored output when set, following no-color.org.
+	noColorEnvVar = "NO_COLOR"
+
 	listDesc = `List all notes in your notes directory. 
 You can sort notes by creation date, modification date, or name.
-Example: notes list --sort-by modified --order newest`
+Use --tag to only show notes carrying every given tag.
+Narrow the list with --since and --until, which compare modification times
+and accept dates (2026-01-31), durations (7d, 12h) or today, yesterday,
+last-week, last-month and last-year; --created-on for a single day;
+--min-size and --max-size (512, 4KB, 1MB); and --name-glob ('standup_*').
+--limit keeps the first notes after sorting.
+Use --all-notebooks to list the notes in every notebook, grouped by notebook.
+Notes inside folders are summarized per folder; use --recursive to list them
+by path, or --tree to show the folder hierarchy.
+Files that can't be read as notes are skipped and reported after the list;
+list them in .noteignore to hide them, or use --strict to stop at the first.
+Notes are shown as a table; use --columns to pick its columns from
+index, id, name, title, created, modified, size, words and tags, and
+--absolute-time to show dates instead of times like "3h ago".
+Use --output json, csv, tsv or ndjson to list every note, folders included,
+in a form other programs can read.
+Use --format to print every note with a Go template instead, for example
+--format '{{.Created.Format "2006-01-02"}} {{.Name}}'. Templates can use the
+fields of a note (.Name, .Path, .Title, .Tags, .Created, .Modified, .Age,
+.Size, .WordCount, ...) and the functions upper, lower, join, truncate,
+pad, date, ago, size and json. As with --output, notes inside folders are
+included and .Name is the note's path relative to the notes directory,
+such as projects/kickoff_2026_01_02_15_04.txt; .FileName is the file name
+alone and .Path the absolute path.
+Example: notes list --sort-by modified --order newest --tag work`
 )
 
 // init registers the list command and its flags with the root command.
@@ -35,6 +97,30 @@ func init() {
 
 	flags.StringP(orderCmd, orderCmdShort, "",
 		fmt.Sprintf("Order by: %s", availableSortOrders()))
+
+	flags.StringArrayP(tagCmd, tagCmdShort, nil, "Only list notes with this tag (repeatable)")
+
+	flags.Bool(allNotebooksCmd, false, "List notes from every notebook")
+
+	flags.BoolP(recursiveCmd, recursiveCmdShort, false, "List notes in folders too, by path")
+
+	flags.Bool(treeCmd, false, "Show notes in their folder hierarchy")
+
+	flags.Bool(strictCmd, false, "Fail on the first file that can't be read as a note")
+
+	flags.StringSlice(columnsCmd, nil, fmt.Sprintf("Table columns to show, comma-separated: %s", availableColumns()))
+
+	flags.Bool(absoluteTimeCmd, false, "Show dates and times instead of relative times")
+
+	flags.String(formatCmd, "", "Print each note using a Go template")
+
+	flags.String(sinceCmd, "", "Only list notes modified since a date or duration ago (2026-01-31, 7d, last-week)")
+	flags.String(untilCmd, "", "Only list notes modified up to a date or duration ago")
+	flags.String(createdOnCmd, "", "Only list notes created on a day (2026-01-31, today, yesterday)")
+	flags.String(minSizeCmd, "", "Only list notes at least this size (512, 4KB, 1MB)")
+	flags.String(maxSizeCmd, "", "Only list notes at most this size")
+	flags.String(nameGlobCmd, "", "Only list notes whose name or path matches a glob pattern")
+	flags.Int(limitCmd, 0, "List at most this many notes, after sorting")
 }
 
 // NewListCommand creates and returns a new cobra.Command for the list functionality.
@@ -59,10 +145,120 @@ func NewListCommand() *cobra.Command {
 				return fmt.Errorf("failed to get order flag: %w", err)
 			}
 
+			tags, err := cmd.Flags().GetStringArray(tagCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get tag flag: %w", err)
+			}
+
+			allNotebooks, err := cmd.Flags().GetBool(allNotebooksCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get all-notebooks flag: %w", err)
+			}
+
+			recursive, err := cmd.Flags().GetBool(recursiveCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get recursive flag: %w", err)
+			}
+
+			tree, err := cmd.Flags().GetBool(treeCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get tree flag: %w", err)
+			}
+
+			strict, err := cmd.Flags().GetBool(strictCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get strict flag: %w", err)
+			}
+
+			columns, err := cmd.Flags().GetStringSlice(columnsCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get columns flag: %w", err)
+			}
+
+			absoluteTime, err := cmd.Flags().GetBool(absoluteTimeCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get absolute-time flag: %w", err)
+			}
+
+			format, err := cmd.Flags().GetString(formatCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get format flag: %w", err)
+			}
+
+			since, err := cmd.Flags().GetString(sinceCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get since flag: %w", err)
+			}
+
+			until, err := cmd.Flags().GetString(untilCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get until flag: %w", err)
+			}
+
+			createdOn, err := cmd.Flags().GetString(createdOnCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get created-on flag: %w", err)
+			}
+
+			minSize, err := cmd.Flags().GetString(minSizeCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get min-size flag: %w", err)
+			}
+
+			maxSize, err := cmd.Flags().GetString(maxSizeCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get max-size flag: %w", err)
+			}
+
+			nameGlob, err := cmd.Flags().GetString(nameGlobCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get name-glob flag: %w", err)
+			}
+
+			limit, err := cmd.Flags().GetInt(limitCmd)
+			if err != nil {
+				return fmt.Errorf("failed to get limit flag: %w", err)
+			}
+
+			if allNotebooks {
+				defaultDir, err := root.DirManager.DefaultNotebookDir()
+				if err != nil {
+					return err
+				}
+				listCmd.Notebooks = append(
+					[]notebook.Notebook{{Name: notebook.DefaultName, Dir: defaultDir}},
+					root.Notebooks.List()...,
+				)
+			}
+
 			listCmd.SortField = SortField(sortBy)
 			listCmd.SortOrder = SortOrder(order)
+			listCmd.DefaultSortField = SortField(root.AppConfig.ListSortBy)
+			listCmd.DefaultSortOrder = SortOrder(root.AppConfig.ListOrder)
+			listCmd.Tags = tags
+			listCmd.Since = since
+			listCmd.Until = until
+			listCmd.CreatedOn = createdOn
+			listCmd.MinSize = minSize
+			listCmd.MaxSize = maxSize
+			listCmd.NameGlob = nameGlob
+			listCmd.Limit = limit
+			listCmd.AllNotebooks = allNotebooks
+			listCmd.Recursive = recursive
+			listCmd.Tree = tree
+			listCmd.Strict = strict
+			listCmd.Output = root.Output
+			listCmd.Columns = make([]Column, len(columns))
+			for i, column := range columns {
+				listCmd.Columns[i] = Column(column)
+			}
+			listCmd.AbsoluteTime = absoluteTime
+			listCmd.Format = format
+			listCmd.Color = terminal.IsTerminal(os.Stdout) && os.Getenv(noColorEnvVar) == ""
+			listCmd.Width = terminal.Width(os.Stdout)
+			listCmd.Notebook = root.ActiveNotebook()
 
-			return listCmd.Run(root.AppLogger, root.DirManager)
+			return listCmd.Run(cmd.Context(), root.AppLogger, root.DirManager)
 		},
 	}
 	return cmd
@@ -70,7 +266,7 @@ func NewListCommand() *cobra.Command {
 
 // Run executes the list command with the specified options.
 // It completes default values, validates inputs, and processes the notes.
-func (opts *ListOptions) Run(logger *logger.Logger, dm *filesystem.DirectoryManager) error {
+func (opts *ListOptions) Run(ctx context.Context, logger *logger.Logger, dm *filesystem.DirectoryManager) error {
 	if err := opts.complete(); err != nil {
 		return fmt.Errorf("invalid options: %w", err)
 	}
@@ -79,14 +275,31 @@ func (opts *ListOptions) Run(logger *logger.Logger, dm *filesystem.DirectoryMana
 		return fmt.Errorf("invalid options: %w", err)
 	}
 
-	notesDir := dm.NotesDir()
-
-	logger.Info("Reading notes from directory")
-	files, err := file.PrepareNoteFiles(logger, notesDir)
-	if err != nil {
-		return fmt.Errorf("failed to get files: %w", err)
+	if opts.AllNotebooks {
+		if err := opts.loadNotebooks(ctx, logger); err != nil {
+			return err
+		}
+	} else {
+		notesDir := dm.NotesDir()
+
+		logger.Info("Reading notes from directory")
+		files, warnings, err := file.LoadNotes(ctx, logger, notesDir, file.LoadOptions{Strict: opts.Strict})
+		if err != nil {
+			return fmt.Errorf("failed to get files: %w", err)
+		}
+		opts.warnings = warnings
+
+		folders, err := file.ReadFolders(notesDir)
+		if err != nil {
+			return fmt.Errorf("failed to get folders: %w", err)
+		}
+
+		opts.sections = []notebookSection{{
+			notebook: notebook.Notebook{Name: opts.Notebook, Dir: notesDir},
+			files:    opts.filterFiles(files),
+			folders:  folders,
+		}}
 	}
-	opts.files = files
 
 	if err := opts.execute(); err != nil {
 		return fmt.Errorf("could not execute command: %w", err)
@@ -95,14 +308,76 @@ func (opts *ListOptions) Run(logger *logger.Logger, dm *filesystem.DirectoryMana
 	return nil
 }
 
-// complete sets default values for sorting options.
-// If no sort field is specified, defaults to sorting by name in alphabetical order.
+// loadNotebooks reads the notes of every notebook in opts.Notebooks. Empty
+// notebooks are listed without notes rather than treated as an error.
+func (opts *ListOptions) loadNotebooks(ctx context.Context, logger *logger.Logger) error {
+	for _, nb := range opts.Notebooks {
+		logger.Info(fmt.Sprintf("Reading notes from notebook %q", nb.Name))
+
+		section := notebookSection{notebook: nb}
+
+		entries, err := os.ReadDir(nb.Dir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read notebook %q: %w", nb.Name, err)
+		}
+		if len(entries) > 0 {
+			files, warnings, err := file.LoadNotes(ctx, logger, nb.Dir, file.LoadOptions{Strict: opts.Strict})
+			if err != nil {
+				return fmt.Errorf("failed to get files for notebook %q: %w", nb.Name, err)
+			}
+			section.files = opts.filterFiles(files)
+
+			for _, warning := range warnings {
+				warning.Path = nb.Name + ": " + warning.Path
+				opts.warnings = append(opts.warnings, warning)
+			}
+
+			if section.folders, err = file.ReadFolders(nb.Dir); err != nil {
+				return fmt.Errorf("failed to get folders for notebook %q: %w", nb.Name, err)
+			}
+		}
+
+		opts.sections = append(opts.sections, section)
+	}
+	return nil
+}
+
+// complete sets default values for sorting and output options.
+// If no sort field is specified, the configured defaults are used, falling
+// back to sorting by name in alphabetical order. A sort field given without
+// an order is sorted alphabetically or newest first.
 func (opts *ListOptions) complete() error {
+	if opts.Output == "" {
+		opts.Output = output.FormatTable
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.ErrOut == nil {
+		opts.ErrOut = os.Stderr
+	}
+	if opts.now.IsZero() {
+		opts.now = time.Now()
+	}
+
+	if opts.SortField == "" {
+		opts.SortField = opts.DefaultSortField
+		opts.SortOrder = opts.DefaultSortOrder
+	}
+
 	if opts.SortField == "" {
 		opts.SortField = SortFieldName
 		opts.SortOrder = SortOrderAlph
 	}
 
+	// --sort-by without --order gets the order that suits the field.
+	if opts.SortOrder == "" {
+		opts.SortOrder = SortOrderNewest
+		if opts.SortField == SortFieldName {
+			opts.SortOrder = SortOrderAlph
+		}
+	}
+
 	return nil
 }
 
@@ -112,21 +387,107 @@ func (opts *ListOptions) validate() error {
 	return v.Run(opts)
 }
 
-// execute performs the note sorting and displays the results to stdout.
+// execute sorts the notes and writes them to opts.Out in the selected
+// output format.
 func (opts *ListOptions) execute() error {
-	sortFiles(opts.files, opts.SortField, opts.SortOrder)
-
-	//TODO: Improve how files are displayed
-	fmt.Println(getHeader(opts.SortField, opts.SortOrder))
-	fmt.Println()
+	for i := range opts.sections {
+		section := &opts.sections[i]
+		sortFiles(section.files, opts.SortField, opts.SortOrder)
+
+		// The default table limits the top-level notes it shows itself,
+		// after counting every note in each folder.
+		if !opts.summarizesFolders() {
+			section.files = opts.limitFiles(section.files)
+		}
+	}
 
-	for _, file := range opts.files {
-		fmt.Println(file.Name)
+	switch {
+	case opts.Output.Structured():
+		if err := opts.writeStructured(); err != nil {
+			return fmt.Errorf("failed to write %s output: %w", opts.Output, err)
+		}
+	case opts.template != nil:
+		if err := opts.writeTemplate(); err != nil {
+			return err
+		}
+	default:
+		opts.printTable()
 	}
 
+	printWarnings(opts.ErrOut, opts.warnings)
 	return nil
 }
 
+// writeStructured writes every note, including those inside folders, in a
+// machine-readable format.
+func (opts *ListOptions) writeStructured() error {
+	var notes []output.Note
+	for _, section := range opts.sections {
+		for _, f := range section.files {
+			notes = append(notes, output.NewNote(f, section.notebook.Name))
+		}
+	}
+	return output.Write(opts.Out, opts.Output, notes)
+}
+
+// printTable prints the notes for people to read, under a header describing
+// the sort order.
+func (opts *ListOptions) printTable() {
+	fmt.Fprintln(opts.Out, getHeader(opts.SortField, opts.SortOrder))
+	fmt.Fprintln(opts.Out)
+
+	for i, section := range opts.sections {
+		if opts.AllNotebooks {
+			if i > 0 {
+				fmt.Fprintln(opts.Out)
+			}
+			fmt.Fprintf(opts.Out, "[%s] %s\n", section.notebook.Name, section.notebook.Dir)
+
+			if len(section.files) == 0 && len(section.folders) == 0 {
+				fmt.Fprintln(opts.Out, "  (no notes)")
+				continue
+			}
+		}
+
+		opts.printSection(section)
+	}
+}
+
+// printWarnings summarizes the files skipped while loading notes. It writes
+// to w, normally stderr, so the list itself can still be piped.
+func printWarnings(w io.Writer, warnings []file.LoadWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nSkipped %d files that couldn't be read as notes:\n", len(warnings))
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "  %s\n", warning.Error())
+	}
+	fmt.Fprintf(w, "Add them to %s to hide this warning.\n", file.IgnoreFileName)
+}
+
+// printSection prints a notebook's notes in the selected view.
+func (opts *ListOptions) printSection(section notebookSection) {
+	switch {
+	case opts.Tree:
+		printTree(opts.Out, buildTree(section.files, section.folders))
+
+	case opts.Recursive:
+		opts.printNotesTable(opts.Out, section.files, nil, nil)
+
+	default:
+		names, counts := subfolderCounts(section.files, section.folders)
+
+		// With a filter, only folders holding matching notes are relevant.
+		if opts.filtered() {
+			names = slices.DeleteFunc(names, func(name string) bool { return counts[name] == 0 })
+		}
+
+		opts.printNotesTable(opts.Out, opts.limitFiles(topLevel(section.files)), names, counts)
+	}
+}
+
 // availableSortFields returns a comma-separated string of valid sort field options.
 func availableSortFields() string {
 	fields := []string{
@@ -148,6 +509,15 @@ func availableSortOrders() string {
 	return strings.Join(orders, ", ")
 }
 
+// availableColumns returns a comma-separated string of valid table columns.
+func availableColumns() string {
+	names := make([]string, len(allColumns))
+	for i, column := range allColumns {
+		names[i] = string(column)
+	}
+	return strings.Join(names, ", ")
+}
+
 // getHeader returns a formatted string describing the current sort configuration.
 func getHeader(field SortField, order SortOrder) string {
 	fieldDescription := sortFieldDescriptions[field]
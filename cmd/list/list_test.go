@@ -0,0 +1,83 @@
+package list
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/output"
+)
+
+func TestLimitAppliesToDisplayedNotes(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	note := func(folder, name string, age time.Duration) file.File {
+		return file.File{Name: name + ".txt", Folder: folder, DateCreated: now.Add(-age)}
+	}
+
+	// The newest notes are inside folders, so a limit applied before hiding
+	// them would leave no top-level notes to show.
+	files := []file.File{
+		note("work", "nested-a", time.Hour),
+		note("work/meetings", "nested-b", 2*time.Hour),
+		note("", "top-new", 3*time.Hour),
+		note("", "top-mid", 4*time.Hour),
+		note("", "top-old", 5*time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:    "default view",
+			want:    []string{"top-new", "top-mid", "work/  (2 notes)"},
+			notWant: []string{"top-old", "nested"},
+		},
+		{
+			name:      "recursive",
+			recursive: true,
+			want:      []string{"work/nested-a", "work/meetings/nested-b"},
+			notWant:   []string{"top-new"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			opts := &ListOptions{
+				SortField: SortFieldCreated,
+				SortOrder: SortOrderNewest,
+				Recursive: tt.recursive,
+				Limit:     2,
+				Output:    output.FormatTable,
+				Columns:   []Column{ColumnName},
+				Out:       &out,
+				ErrOut:    io.Discard,
+				now:       now,
+				sections: []notebookSection{{
+					files:   append([]file.File(nil), files...),
+					folders: []string{"work", "work/meetings"},
+				}},
+			}
+
+			if err := opts.execute(); err != nil {
+				t.Fatalf("execute() error = %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output has %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
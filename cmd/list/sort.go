@@ -21,9 +21,9 @@ func compareFiles(a, b file.File, field SortField, order SortOrder) bool {
 
 	case SortFieldName:
 		if order == SortOrderAlph {
-			return a.Name < b.Name
+			return a.RelPath() < b.RelPath()
 		}
-		return a.Name > b.Name
+		return a.RelPath() > b.RelPath()
 
 	case SortFieldCreated:
 		if order == SortOrderNewest {
@@ -38,6 +38,6 @@ func compareFiles(a, b file.File, field SortField, order SortOrder) bool {
 		return a.DateCreated.Before(b.DateModified)
 
 	default:
-		return a.Name < b.Name
+		return a.RelPath() < b.RelPath()
 	}
 }
@@ -0,0 +1,243 @@
+package list
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/timeutil"
+)
+
+const (
+	// ANSI escape codes used when writing to a terminal.
+	headingStart = "\033[1m"
+	folderStart  = "\033[1;34m"
+	tagsStart    = "\033[36m"
+	colorEnd     = "\033[0m"
+
+	absoluteTimeFormat = "2006-01-02 15:04"
+
+	columnGap = "  "
+	ellipsis  = "…"
+
+	// minColumnWidth is the narrowest a column is truncated to when the
+	// table doesn't fit the terminal.
+	minColumnWidth = 8
+)
+
+// tableRow is a line of the table: a note, or a folder summarized by the
+// number of notes inside it.
+type tableRow struct {
+	cells  []string
+	folder bool
+}
+
+// printNotesTable prints files, followed by a summary line for each folder
+// in folders, as a table of the selected columns.
+func (opts *ListOptions) printNotesTable(w io.Writer, files []file.File, folders []string, counts map[string]int) {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+
+	var rows []tableRow
+	for _, f := range files {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = opts.cellValue(f, column)
+		}
+		rows = append(rows, tableRow{cells: cells})
+	}
+
+	// Folders are described in the name column, or the first if it isn't shown.
+	summaryColumn := max(slices.Index(columns, ColumnName), 0)
+	for _, folder := range folders {
+		cells := make([]string, len(columns))
+		cells[summaryColumn] = fmt.Sprintf("%s/  (%s)", folder, noteCount(counts[folder]))
+		rows = append(rows, tableRow{cells: cells, folder: true})
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	headings := make([]string, len(columns))
+	for i, column := range columns {
+		headings[i] = columnHeadings[column]
+	}
+
+	widths := fitColumns(columns, columnWidths(headings, rows), opts.Width)
+
+	opts.printRow(w, columns, widths, headings, headingStart)
+	for _, row := range rows {
+		color := ""
+		if row.folder {
+			color = folderStart
+		}
+		opts.printRow(w, columns, widths, row.cells, color)
+	}
+}
+
+// cellValue formats a single field of a note.
+func (opts *ListOptions) cellValue(f file.File, column Column) string {
+	switch column {
+	case ColumnIndex:
+		return strconv.Itoa(f.Index)
+	case ColumnID:
+		return file.ShortID(f.ID)
+	case ColumnName:
+		return path.Join(f.Folder, file.DisplayName(f.Name))
+	case ColumnTitle:
+		return f.Title
+	case ColumnCreated:
+		return opts.formatTime(f.DateCreated)
+	case ColumnModified:
+		return opts.formatTime(f.DateModified)
+	case ColumnSize:
+		return formatSize(f.Size)
+	case ColumnWords:
+		return strconv.Itoa(f.WordCount)
+	case ColumnTags:
+		return strings.Join(f.Tags, ", ")
+	default:
+		return ""
+	}
+}
+
+// printRow writes one line of the table, truncating cells to their column's
+// width. color, if set, applies to the whole line; otherwise tags are
+// highlighted.
+func (opts *ListOptions) printRow(w io.Writer, columns []Column, widths []int, cells []string, color string) {
+	var line strings.Builder
+
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString(columnGap)
+		}
+
+		cell = truncate(cell, widths[i])
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		last := i == len(cells)-1
+
+		if opts.Color && color == "" && columns[i] == ColumnTags && cell != "" {
+			cell = tagsStart + cell + colorEnd
+		}
+
+		switch {
+		case rightAligned(columns[i]):
+			line.WriteString(padding + cell)
+		case last:
+			line.WriteString(cell)
+		default:
+			line.WriteString(cell + padding)
+		}
+	}
+
+	text := strings.TrimRight(line.String(), " ")
+	if opts.Color && color != "" {
+		text = color + text + colorEnd
+	}
+	fmt.Fprintln(w, text)
+}
+
+// formatTime shows t relative to now, or as a date with --absolute-time.
+func (opts *ListOptions) formatTime(t time.Time) string {
+	if opts.AbsoluteTime {
+		return t.Format(absoluteTimeFormat)
+	}
+	return timeutil.FormatRelative(t, opts.now)
+}
+
+// columnWidths returns the width of the widest cell, heading included, in
+// each column.
+func columnWidths(headings []string, rows []tableRow) []int {
+	widths := make([]int, len(headings))
+	for i, heading := range headings {
+		widths[i] = utf8.RuneCountInString(heading)
+	}
+
+	for _, row := range rows {
+		for i, cell := range row.cells {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	return widths
+}
+
+// fitColumns narrows the free-text columns, widest first, until the table
+// fits in maxWidth. Numbers and dates are never truncated, so a very narrow
+// terminal may still wrap.
+func fitColumns(columns []Column, widths []int, maxWidth int) []int {
+	if maxWidth <= 0 {
+		return widths
+	}
+
+	total := len(columnGap) * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+
+	for total > maxWidth {
+		widest := -1
+		for i, column := range columns {
+			if truncatable(column) && widths[i] > minColumnWidth && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// truncate shortens s to width characters, marking the cut with an ellipsis.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// formatSize formats a size in bytes for people to read, such as "1.2 KB".
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TB", value)
+}
+
+// noteCount describes a number of notes, such as "1 note" or "3 notes".
+func noteCount(count int) string {
+	if count == 1 {
+		return "1 note"
+	}
+	return fmt.Sprintf("%d notes", count)
+}
+
+func rightAligned(column Column) bool {
+	return column == ColumnIndex || column == ColumnSize || column == ColumnWords
+}
+
+func truncatable(column Column) bool {
+	return column == ColumnName || column == ColumnTitle || column == ColumnTags
+}
@@ -0,0 +1,326 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/timeutil"
+)
+
+// templateNote is what a --format template sees for each note: every field
+// of file.File, such as .Title, .Tags, .Size and .WordCount, plus the fields
+// below. .Name and .Path mean what name and path do in --output json.
+type templateNote struct {
+	file.File
+	Name        string        // path relative to the notes directory
+	Path        string        // absolute path of the note file
+	FileName    string        // file name without its folder
+	Notebook    string        // notebook holding the note
+	DisplayName string        // file name without its timestamp and extension
+	Created     time.Time     // same as .DateCreated
+	Modified    time.Time     // same as .DateModified
+	Age         time.Duration // time since the note was created
+}
+
+// templateFuncs are the helper functions available in --format templates.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join": func(sep string, values []string) string {
+		return strings.Join(values, sep)
+	},
+	"truncate": func(width int, s string) string {
+		if width <= 0 {
+			return ""
+		}
+		return truncate(s, width)
+	},
+	"pad": func(width int, s string) string {
+		return fmt.Sprintf("%-*s", width, s)
+	},
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"ago": func(t time.Time) string {
+		return timeutil.FormatRelative(t, time.Now())
+	},
+	"size": formatSize,
+	"json": func(v any) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+}
+
+// parseFormat parses a --format template and checks every field it refers
+// to against the fields of a note, including those in branches and range
+// bodies that an empty note wouldn't reach, so a misspelled field is
+// reported before anything is listed.
+func parseFormat(format string) (*template.Template, error) {
+	tmpl, err := template.New(formatCmd).Funcs(templateFuncs).Option("missingkey=error").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s template: %w", formatCmd, err)
+	}
+
+	noteType := reflect.TypeOf(templateNote{})
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		// Templates run with {{template}} may be given any value as dot.
+		var dot reflect.Type
+		if t.Name() == formatCmd {
+			dot = noteType
+		}
+
+		checker := fieldChecker{root: dot}
+		if err := checker.checkNode(t.Tree.Root, dot); err != nil {
+			return nil, fmt.Errorf("invalid --%s template: %w", formatCmd, err)
+		}
+	}
+
+	return tmpl, nil
+}
+
+// writeTemplate writes every note, including those inside folders, using
+// the --format template, one note per line. Nothing is written unless every
+// note formats successfully.
+func (opts *ListOptions) writeTemplate() error {
+	var buf bytes.Buffer
+
+	for _, section := range opts.sections {
+		for _, f := range section.files {
+			note := templateNote{
+				File:        f,
+				Name:        f.RelPath(),
+				Path:        f.FilePath,
+				FileName:    f.Name,
+				Notebook:    section.notebook.Name,
+				DisplayName: file.DisplayName(f.Name),
+				Created:     f.DateCreated,
+				Modified:    f.DateModified,
+				Age:         opts.now.Sub(f.DateCreated).Truncate(time.Second),
+			}
+
+			if err := opts.template.Execute(&buf, note); err != nil {
+				return fmt.Errorf("failed to format %q: %w", f.RelPath(), err)
+			}
+			buf.WriteByte('\n')
+		}
+	}
+
+	_, err := buf.WriteTo(opts.Out)
+	return err
+}
+
+// fieldChecker follows the type of dot through a parsed template to find
+// fields that don't exist. A nil type means the type isn't known statically,
+// such as a variable's or a built-in function's result; fields of it aren't
+// checked.
+type fieldChecker struct {
+	root reflect.Type // the type of $
+}
+
+func (c fieldChecker) checkNode(node parse.Node, dot reflect.Type) error {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+		for _, child := range node.Nodes {
+			if err := c.checkNode(child, dot); err != nil {
+				return err
+			}
+		}
+
+	case *parse.ActionNode:
+		_, err := c.pipeType(node.Pipe, dot)
+		return err
+
+	case *parse.IfNode:
+		return c.checkBranch(node.Pipe, node.List, node.ElseList, dot, dot)
+
+	case *parse.WithNode:
+		inner, err := c.pipeType(node.Pipe, dot)
+		if err != nil {
+			return err
+		}
+		return c.checkBranch(nil, node.List, node.ElseList, inner, dot)
+
+	case *parse.RangeNode:
+		ranged, err := c.pipeType(node.Pipe, dot)
+		if err != nil {
+			return err
+		}
+		return c.checkBranch(nil, node.List, node.ElseList, elemType(ranged), dot)
+
+	case *parse.TemplateNode:
+		if node.Pipe != nil {
+			_, err := c.pipeType(node.Pipe, dot)
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBranch checks an optional condition, then list with dot set to inner
+// and elseList with dot unchanged.
+func (c fieldChecker) checkBranch(pipe *parse.PipeNode, list, elseList *parse.ListNode, inner, dot reflect.Type) error {
+	if pipe != nil {
+		if _, err := c.pipeType(pipe, dot); err != nil {
+			return err
+		}
+	}
+	if err := c.checkNode(list, inner); err != nil {
+		return err
+	}
+	return c.checkNode(elseList, dot)
+}
+
+// pipeType checks every command in pipe and returns the type of its result.
+func (c fieldChecker) pipeType(pipe *parse.PipeNode, dot reflect.Type) (reflect.Type, error) {
+	var result reflect.Type
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args[1:] {
+			if _, err := c.argType(arg, dot); err != nil {
+				return nil, err
+			}
+		}
+
+		t, err := c.argType(cmd.Args[0], dot)
+		if err != nil {
+			return nil, err
+		}
+		result = t
+	}
+	return result, nil
+}
+
+// argType returns the type of a single operand.
+func (c fieldChecker) argType(arg parse.Node, dot reflect.Type) (reflect.Type, error) {
+	switch arg := arg.(type) {
+	case *parse.DotNode:
+		return dot, nil
+
+	case *parse.FieldNode:
+		return fieldChainType(dot, arg.Ident)
+
+	case *parse.VariableNode:
+		if arg.Ident[0] != "$" {
+			return nil, nil
+		}
+		return fieldChainType(c.root, arg.Ident[1:])
+
+	case *parse.ChainNode:
+		t, err := c.argType(arg.Node, dot)
+		if err != nil {
+			return nil, err
+		}
+		return fieldChainType(t, arg.Field)
+
+	case *parse.PipeNode:
+		return c.pipeType(arg, dot)
+
+	case *parse.IdentifierNode:
+		if fn, ok := templateFuncs[arg.Ident]; ok {
+			if fnType := reflect.TypeOf(fn); fnType.NumOut() > 0 {
+				return fnType.Out(0), nil
+			}
+		}
+		return nil, nil
+	}
+	return nil, nil
+}
+
+// fieldChainType returns the type reached by following the named fields or
+// methods from t.
+func fieldChainType(t reflect.Type, names []string) (reflect.Type, error) {
+	for _, name := range names {
+		if t == nil {
+			return nil, nil
+		}
+		if method, ok := t.MethodByName(name); ok {
+			if method.Type.NumOut() == 0 {
+				return nil, nil
+			}
+			t = method.Type.Out(0)
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Interface:
+			return nil, nil
+		case reflect.Pointer:
+			t = t.Elem()
+			if t.Kind() != reflect.Struct {
+				return nil, unknownFieldError(name, t)
+			}
+			fallthrough
+		case reflect.Struct:
+			field, ok := t.FieldByName(name)
+			if !ok || !field.IsExported() {
+				return nil, unknownFieldError(name, t)
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, unknownFieldError(name, t)
+		}
+	}
+	return t, nil
+}
+
+// elemType returns the type of dot inside {{range}} over a value of type t.
+func elemType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+		return t.Elem()
+	case reflect.Int, reflect.Int64:
+		return t
+	default:
+		return nil
+	}
+}
+
+func unknownFieldError(name string, t reflect.Type) error {
+	if t == reflect.TypeOf(templateNote{}) {
+		return fmt.Errorf("unknown field %q. Available fields: %s", name, strings.Join(templateFields(), ", "))
+	}
+	return fmt.Errorf("unknown field %q in %s", name, t)
+}
+
+// templateFields returns the names of the fields a template can use, sorted.
+func templateFields() []string {
+	var fields []string
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if field.Anonymous {
+				collect(field.Type)
+				continue
+			}
+			if field.IsExported() {
+				fields = append(fields, "."+field.Name)
+			}
+		}
+	}
+	collect(reflect.TypeOf(templateNote{}))
+
+	// Fields of templateNote hide those of file.File with the same name.
+	sort.Strings(fields)
+	return slices.Compact(fields)
+}
@@ -0,0 +1,69 @@
+package list
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr string
+	}{
+		{format: "{{.Name}} {{.Title}} {{join \", \" .Tags}}"},
+		{format: "{{.Created.Year}} {{.RelPath}} {{$.Notebook}} {{(.Modified).Month}}"},
+		{format: "{{range $i, $tag := .Tags}}{{$i}}={{$tag}}{{else}}{{.Name}}{{end}}"},
+		{format: "{{with .Title}}{{len .}}{{end}} {{.Title | upper | len}}"},
+		{format: "{{index .Tags 0}}"},
+		{format: "{{.Bogus}}", wantErr: `unknown field "Bogus". Available fields:`},
+		{format: "{{if .ID}}{{.Bogus}}{{end}}", wantErr: `unknown field "Bogus"`},
+		{format: "{{if .ID}}{{else if .Title}}{{else}}{{.Bogus}}{{end}}", wantErr: `unknown field "Bogus"`},
+		{format: "{{range .Tags}}{{.Nope}}{{end}}", wantErr: `unknown field "Nope" in string`},
+		{format: "{{with .Created}}{{.Nope}}{{end}}", wantErr: `unknown field "Nope" in time.Time`},
+		{format: "{{range .Tags}}{{$.Nope}}{{end}}", wantErr: `unknown field "Nope"`},
+		{format: "{{upper .Nope}}", wantErr: `unknown field "Nope"`},
+		{format: "{{.Title", wantErr: "unclosed action"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			_, err := parseFormat(tt.format)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("parseFormat() error = %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("parseFormat() returned no error, want %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("parseFormat() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteTemplateNameIsRelativePath(t *testing.T) {
+	tmpl, err := parseFormat("{{.Name}} {{.FileName}} {{.Path}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	opts := &ListOptions{
+		Out:      &out,
+		template: tmpl,
+		sections: []notebookSection{{files: []file.File{{
+			Name:     "kick_2026_01_02_15_04.txt",
+			Folder:   "projects/alpha",
+			FilePath: "/notes/projects/alpha/kick_2026_01_02_15_04.txt",
+		}}}},
+	}
+	if err := opts.writeTemplate(); err != nil {
+		t.Fatalf("writeTemplate() error = %v", err)
+	}
+
+	want := "projects/alpha/kick_2026_01_02_15_04.txt kick_2026_01_02_15_04.txt /notes/projects/alpha/kick_2026_01_02_15_04.txt\n"
+	if out.String() != want {
+		t.Errorf("writeTemplate() wrote %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,135 @@
+package list
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+const (
+	treeBranch     = "├── "
+	treeLastBranch = "└── "
+	treeIndent     = "│   "
+	treeLastIndent = "    "
+)
+
+// folderNode is a folder in the tree view with the folders and notes inside it.
+type folderNode struct {
+	name    string
+	folders map[string]*folderNode
+	files   []file.File
+}
+
+func newFolderNode(name string) *folderNode {
+	return &folderNode{name: name, folders: map[string]*folderNode{}}
+}
+
+// buildTree arranges files, already in display order, into their folders.
+// Every folder in folders appears even if it holds no notes.
+func buildTree(files []file.File, folders []string) *folderNode {
+	root := newFolderNode(".")
+
+	for _, folder := range folders {
+		root.folder(folder)
+	}
+	for _, f := range files {
+		node := root.folder(f.Folder)
+		node.files = append(node.files, f)
+	}
+	return root
+}
+
+// folder returns the node for a slash-separated folder path below n,
+// creating any missing nodes along the way.
+func (n *folderNode) folder(folderPath string) *folderNode {
+	node := n
+	if folderPath == "" {
+		return node
+	}
+
+	for _, part := range strings.Split(folderPath, "/") {
+		child, ok := node.folders[part]
+		if !ok {
+			child = newFolderNode(part)
+			node.folders[part] = child
+		}
+		node = child
+	}
+	return node
+}
+
+// printTree prints the folder hierarchy, listing each folder's subfolders
+// before its notes.
+func printTree(w io.Writer, root *folderNode) {
+	fmt.Fprintln(w, root.name)
+	printTreeChildren(w, root, "")
+}
+
+func printTreeChildren(w io.Writer, node *folderNode, prefix string) {
+	names := make([]string, 0, len(node.folders))
+	for name := range node.folders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	total := len(names) + len(node.files)
+	position := 0
+
+	branch := func() (string, string) {
+		position++
+		if position == total {
+			return treeLastBranch, treeLastIndent
+		}
+		return treeBranch, treeIndent
+	}
+
+	for _, name := range names {
+		connector, indent := branch()
+		fmt.Fprintf(w, "%s%s%s/\n", prefix, connector, name)
+		printTreeChildren(w, node.folders[name], prefix+indent)
+	}
+
+	for _, f := range node.files {
+		connector, _ := branch()
+		fmt.Fprintf(w, "%s%s%s  (#%d, %s)\n", prefix, connector, f.Name, f.Index, file.ShortID(f.ID))
+	}
+}
+
+// subfolderCounts returns each top-level folder with the number of files
+// anywhere inside it.
+func subfolderCounts(files []file.File, folders []string) ([]string, map[string]int) {
+	counts := make(map[string]int)
+	var names []string
+
+	for _, folder := range folders {
+		if !strings.Contains(folder, "/") {
+			names = append(names, folder)
+			counts[folder] = 0
+		}
+	}
+
+	for _, f := range files {
+		if f.Folder == "" {
+			continue
+		}
+		top, _, _ := strings.Cut(f.Folder, "/")
+		counts[top]++
+	}
+
+	sort.Strings(names)
+	return names, counts
+}
+
+// topLevel returns the files that aren't inside a folder.
+func topLevel(files []file.File) []file.File {
+	var top []file.File
+	for _, f := range files {
+		if f.Folder == "" {
+			top = append(top, f)
+		}
+	}
+	return top
+}
@@ -1,6 +1,14 @@
 package list
 
-import "github.com/rhysmah/note-app/file"
+import (
+	"io"
+	"text/template"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/notebook"
+	"github.com/rhysmah/note-app/internal/output"
+)
 
 type SortField string
 type SortOrder string
@@ -42,8 +50,88 @@ var sortOrderDescriptions = map[SortOrder]string{
 	SortOrderRAlph:  SortOrderRAlphDesc,
 }
 
+// Column is a field shown in the table view.
+type Column string
+
+const (
+	ColumnIndex    Column = "index"
+	ColumnID       Column = "id"
+	ColumnName     Column = "name"
+	ColumnTitle    Column = "title"
+	ColumnCreated  Column = "created"
+	ColumnModified Column = "modified"
+	ColumnSize     Column = "size"
+	ColumnWords    Column = "words"
+	ColumnTags     Column = "tags"
+)
+
+// columnHeadings maps every column to the heading shown above it.
+var columnHeadings = map[Column]string{
+	ColumnIndex:    "#",
+	ColumnID:       "ID",
+	ColumnName:     "NAME",
+	ColumnTitle:    "TITLE",
+	ColumnCreated:  "CREATED",
+	ColumnModified: "MODIFIED",
+	ColumnSize:     "SIZE",
+	ColumnWords:    "WORDS",
+	ColumnTags:     "TAGS",
+}
+
+var allColumns = []Column{
+	ColumnIndex, ColumnID, ColumnName, ColumnTitle, ColumnCreated,
+	ColumnModified, ColumnSize, ColumnWords, ColumnTags,
+}
+
+// defaultColumns are shown unless --columns picks others.
+var defaultColumns = []Column{
+	ColumnIndex, ColumnID, ColumnName, ColumnCreated,
+	ColumnModified, ColumnSize, ColumnWords, ColumnTags,
+}
+
 type ListOptions struct {
-	SortField SortField
-	SortOrder SortOrder
-	files     []file.File
+	SortField        SortField
+	SortOrder        SortOrder
+	DefaultSortField SortField
+	DefaultSortOrder SortOrder
+	Tags             []string
+	Since            string // only notes modified since this time
+	Until            string // only notes modified before this time
+	CreatedOn        string // only notes created on this day
+	MinSize          string
+	MaxSize          string
+	NameGlob         string
+	Limit            int // list at most this many notes per notebook; 0 for all
+	AllNotebooks     bool
+	Notebooks        []notebook.Notebook // listed when AllNotebooks is set
+	Recursive        bool
+	Tree             bool
+	Strict           bool
+	Output           output.Format
+	Columns          []Column // table columns; defaultColumns if empty
+	AbsoluteTime     bool     // show dates rather than "3h ago"
+	Color            bool
+	Format           string    // text/template applied to each note instead of the table
+	Width            int       // terminal width to fit the table in; 0 for no limit
+	Notebook         string    // name reported for the notes when AllNotebooks isn't set
+	Out              io.Writer // defaults to os.Stdout
+	ErrOut           io.Writer // defaults to os.Stderr
+	now              time.Time
+	since            time.Time
+	until            time.Time
+	createdFrom      time.Time
+	createdTo        time.Time
+	minSize          int64
+	maxSize          int64
+	hasMaxSize       bool
+	template         *template.Template
+	sections         []notebookSection
+	warnings         []file.LoadWarning
+}
+
+// notebookSection holds the notes and folders listed for one notebook.
+type notebookSection struct {
+	notebook notebook.Notebook
+	files    []file.File
+	folders  []string
 }
@@ -2,7 +2,12 @@ package list
 
 import (
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/timeutil"
 	"github.com/rhysmah/note-app/validator"
 )
 
@@ -15,6 +20,15 @@ func NewValidator() *validator.Validator[ListOptions] {
 			validateNameSortOrder,
 			validateSortField,
 			validateOrderField,
+			validateTags,
+			validateDateRange,
+			validateCreatedOn,
+			validateSizeRange,
+			validateNameGlob,
+			validateLimit,
+			validateOutputView,
+			validateColumns,
+			validateFormat,
 		},
 	}
 }
@@ -65,3 +79,159 @@ func validateOrderField(opts *ListOptions) error {
 	}
 	return nil
 }
+
+// validateTags normalizes the tags to filter by so they match stored tags.
+func validateTags(opts *ListOptions) error {
+	tags, err := file.NormalizeTags(opts.Tags)
+	if err != nil {
+		return fmt.Errorf("invalid tag filter: %w", err)
+	}
+	opts.Tags = tags
+	return nil
+}
+
+// validateOutputView rejects views that only make sense for people when a
+// machine-readable output format is selected.
+func validateOutputView(opts *ListOptions) error {
+	if opts.Output.Structured() && opts.Tree {
+		return fmt.Errorf("--%s cannot be used with --output %s", treeCmd, opts.Output)
+	}
+	return nil
+}
+
+// validateColumns checks the table columns, normalizing their case and
+// dropping duplicates.
+func validateColumns(opts *ListOptions) error {
+	if len(opts.Columns) == 0 {
+		return nil
+	}
+	if opts.Output.Structured() || opts.Tree {
+		return fmt.Errorf("--%s only applies to the table view", columnsCmd)
+	}
+
+	columns := make([]Column, 0, len(opts.Columns))
+	for _, column := range opts.Columns {
+		column = Column(strings.ToLower(strings.TrimSpace(string(column))))
+		if _, valid := columnHeadings[column]; !valid {
+			return fmt.Errorf("invalid column: %q. Valid columns: %q", column, availableColumns())
+		}
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	opts.Columns = columns
+	return nil
+}
+
+// validateFormat parses the --format template, which replaces the table and
+// so can't be combined with other ways of choosing the output.
+func validateFormat(opts *ListOptions) error {
+	if opts.Format == "" {
+		return nil
+	}
+
+	switch {
+	case opts.Output.Structured():
+		return fmt.Errorf("--%s cannot be used with --output %s", formatCmd, opts.Output)
+	case opts.Tree:
+		return fmt.Errorf("--%s cannot be used with --%s", formatCmd, treeCmd)
+	case len(opts.Columns) > 0:
+		return fmt.Errorf("--%s cannot be used with --%s", formatCmd, columnsCmd)
+	}
+
+	tmpl, err := parseFormat(opts.Format)
+	if err != nil {
+		return err
+	}
+	opts.template = tmpl
+	return nil
+}
+
+// validateDateRange parses the --since and --until times. An --until date
+// without a time of day includes the whole of that day.
+func validateDateRange(opts *ListOptions) error {
+	if opts.Since != "" {
+		since, err := timeutil.ParseTime(opts.Since, opts.now)
+		if err != nil {
+			return fmt.Errorf("invalid --%s value: %w", sinceCmd, err)
+		}
+		opts.since = since
+	}
+
+	if opts.Until != "" {
+		until, err := timeutil.ParseTime(opts.Until, opts.now)
+		if err != nil {
+			return fmt.Errorf("invalid --%s value: %w", untilCmd, err)
+		}
+		if timeutil.IsWholeDay(opts.Until) {
+			until = until.AddDate(0, 0, 1)
+		}
+		opts.until = until
+	}
+
+	if !opts.since.IsZero() && !opts.until.IsZero() && !opts.since.Before(opts.until) {
+		return fmt.Errorf("--%s (%s) must be before --%s (%s)", sinceCmd, opts.Since, untilCmd, opts.Until)
+	}
+	return nil
+}
+
+// validateCreatedOn parses the --created-on day into the range of creation
+// times it covers.
+func validateCreatedOn(opts *ListOptions) error {
+	if opts.CreatedOn == "" {
+		return nil
+	}
+
+	day, err := timeutil.ParseTime(opts.CreatedOn, opts.now)
+	if err != nil {
+		return fmt.Errorf("invalid --%s value: %w", createdOnCmd, err)
+	}
+
+	opts.createdFrom = timeutil.StartOfDay(day)
+	opts.createdTo = opts.createdFrom.AddDate(0, 0, 1)
+	return nil
+}
+
+// validateSizeRange parses the --min-size and --max-size values.
+func validateSizeRange(opts *ListOptions) error {
+	if opts.MinSize != "" {
+		minSize, err := parseSize(opts.MinSize)
+		if err != nil {
+			return fmt.Errorf("invalid --%s value: %w", minSizeCmd, err)
+		}
+		opts.minSize = minSize
+	}
+
+	if opts.MaxSize != "" {
+		maxSize, err := parseSize(opts.MaxSize)
+		if err != nil {
+			return fmt.Errorf("invalid --%s value: %w", maxSizeCmd, err)
+		}
+		opts.maxSize = maxSize
+		opts.hasMaxSize = true
+	}
+
+	if opts.hasMaxSize && opts.minSize > opts.maxSize {
+		return fmt.Errorf("--%s (%s) cannot be larger than --%s (%s)", minSizeCmd, opts.MinSize, maxSizeCmd, opts.MaxSize)
+	}
+	return nil
+}
+
+// validateNameGlob checks that the --name-glob pattern is well formed.
+func validateNameGlob(opts *ListOptions) error {
+	if opts.NameGlob == "" {
+		return nil
+	}
+	if _, err := path.Match(opts.NameGlob, ""); err != nil {
+		return fmt.Errorf("invalid --%s pattern %q: %w", nameGlobCmd, opts.NameGlob, err)
+	}
+	return nil
+}
+
+// validateLimit checks that --limit isn't negative.
+func validateLimit(opts *ListOptions) error {
+	if opts.Limit < 0 {
+		return fmt.Errorf("--%s must be zero or more, got %d", limitCmd, opts.Limit)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package move
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/spf13/cobra"
+)
+
+const (
+	mvCmd      = "mv"
+	mvCmdShort = "Move notes into a folder"
+	mvCmdDesc  = `Move one or more notes into a folder inside the notes directory.
+The folder is created if it doesn't exist; use '.' for the top level.
+Notes can be given by their full file name, the name they were created with,
+a unique prefix of their ID, or their number in 'list'.
+Example: note-app mv kickoff standup projects/alpha`
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	folderPermissions = 0755
+)
+
+func init() {
+	newMoveCommand := NewMoveCommand()
+	root.RootCmd.AddCommand(newMoveCommand)
+}
+
+func NewMoveCommand() *cobra.Command {
+	moveCmdOpts := &MoveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   mvCmd + " [note]... [folder]",
+		Short: mvCmdShort,
+		Long:  mvCmdDesc,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Moving notes %q", args))
+
+			moveCmdOpts.notesDir = root.DirManager.NotesDir()
+			moveCmdOpts.noteNames = args[:len(args)-1]
+			moveCmdOpts.folder = file.CleanFolder(args[len(args)-1])
+
+			if err := moveNotes(moveCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to move notes: %v", err))
+				return err
+			}
+
+			root.AppLogger.End("Notes moved successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func moveNotes(opts *MoveOptions) error {
+	if err := file.ValidateFolder(opts.folder); err != nil {
+		return fmt.Errorf("invalid folder: %w", err)
+	}
+
+	files, err := file.PrepareNoteFiles(root.AppLogger, opts.notesDir)
+	if err != nil {
+		return err
+	}
+
+	// Resolve every note before moving any, so a typo doesn't leave the move
+	// half done. A note named twice, by name and by ID say, is moved once.
+	notes := make([]*file.File, 0, len(opts.noteNames))
+	resolved := make(map[string]bool)
+	var unresolved []string
+	for _, name := range opts.noteNames {
+		note, err := file.ResolveNote(files, name)
+		if err != nil {
+			unresolved = append(unresolved, err.Error())
+			continue
+		}
+		if resolved[note.FilePath] {
+			continue
+		}
+		resolved[note.FilePath] = true
+		notes = append(notes, note)
+	}
+	if len(unresolved) > 0 {
+		return fmt.Errorf("could not resolve notes:\n  %s", strings.Join(unresolved, "\n  "))
+	}
+
+	targetDir := filepath.Join(opts.notesDir, filepath.FromSlash(opts.folder))
+	if err := os.MkdirAll(targetDir, folderPermissions); err != nil {
+		return fmt.Errorf("failed to create folder %q: %w", opts.folder, err)
+	}
+
+	var moved, paths []string
+	var moveErr error
+	for _, note := range notes {
+		ok, err := moveNote(note, opts.folder, targetDir)
+		if err != nil {
+			moveErr = err
+			break
+		}
+		if ok {
+			moved = append(moved, note.Name)
+			paths = append(paths, note.FilePath, filepath.Join(targetDir, note.Name))
+		}
+	}
+
+	// Record whatever was moved, even if a later note failed.
+	if len(moved) > 0 {
+		root.RecordChange(fmt.Sprintf("Move %s to %s", strings.Join(moved, ", "), folderLabel(opts.folder)), paths...)
+	}
+	return moveErr
+}
+
+// moveNote moves a single note into targetDir, reporting whether it moved.
+func moveNote(note *file.File, folder, targetDir string) (bool, error) {
+	if note.Folder == folder {
+		fmt.Printf("%s is already in %s\n", note.RelPath(), folderLabel(folder))
+		return false, nil
+	}
+
+	newRelPath := path.Join(folder, note.Name)
+	newPath := filepath.Join(targetDir, note.Name)
+	if _, err := os.Stat(newPath); err == nil {
+		return false, fmt.Errorf("note %q already exists", newRelPath)
+	}
+
+	// The ID of a note without one of its own is derived from its path, so
+	// give it a permanent ID before the path changes.
+	if err := file.AssignID(note); err != nil {
+		return false, fmt.Errorf("failed to assign an ID to %q: %w", note.RelPath(), err)
+	}
+
+	if err := os.Rename(note.FilePath, newPath); err != nil {
+		return false, fmt.Errorf("failed to move %q: %w", note.RelPath(), err)
+	}
+
+	root.AppLogger.Success(fmt.Sprintf("Moved %q to %q", note.RelPath(), newRelPath))
+	fmt.Printf("Moved note: %s -> %s\n", note.RelPath(), newRelPath)
+	return true, nil
+}
+
+// folderLabel names a folder for messages, describing the top level in words.
+func folderLabel(folder string) string {
+	if folder == "" {
+		return "the top level"
+	}
+	return folder + "/"
+}
@@ -0,0 +1,7 @@
+package move
+
+type MoveOptions struct {
+	noteNames []string
+	folder    string
+	notesDir  string
+}
@@ -1,20 +1,26 @@
 package new
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"time"
 
 	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/editor"
+	"github.com/rhysmah/note-app/internal/terminal"
 	"github.com/spf13/cobra"
 )
 
 const (
-	illegalChars      string = "\\/:*?\"<>|: ."
-	noteNameCharLimit int    = 50
-	dateTimeFormat    string = "2006_01_02_15_04"
+	// Octal: 4 = read, 2 = write, 1 = execute
+	notePermissions   = 0644
+	folderPermissions = 0755
 )
 
 const (
@@ -22,7 +28,25 @@ const (
 	createCmdShort = "Create a new note"
 	createCmdDesc  = `Create a new note with the specified name.
 The note will be saved as '[note-name]_[date].txt' in your notes directory.
-Note names cannot contain special characters or exceed 50 characters.`
+Prefix the name with folders, as in 'projects/alpha/kickoff', to save the
+note in a folder; missing folders are created.
+Note names cannot contain special characters or exceed the configured
+length limit (50 characters by default).
+
+Initial content can be given with --message, piped through stdin, or written
+in your editor with --edit. Notes written with --edit are only saved if the
+buffer is not empty.
+Tags can be attached with --tag, once per tag.
+Example: echo "call Sam" | note-app create todo --tag work`
+
+	messageFlag      = "message"
+	messageFlagShort = "m"
+	editFlag         = "edit"
+	editFlagShort    = "e"
+	tagFlag          = "tag"
+	tagFlagShort     = "t"
+
+	editBufferPattern = "note-app-*.txt"
 )
 
 func init() {
@@ -53,27 +77,94 @@ func NewCreateCommand() *cobra.Command {
 			return nil
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createCmd.message, messageFlag, messageFlagShort, "", "Initial content of the note")
+	flags.BoolVarP(&createCmd.useEditor, editFlag, editFlagShort, false, "Write the note's content in your editor before saving")
+	flags.StringArrayVarP(&createCmd.tags, tagFlag, tagFlagShort, nil, "Tag to attach to the note (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive(messageFlag, editFlag)
+
 	return cmd
 }
 
 func createNote(opts *NewOptions) error {
 
-	if err := validateNoteName(opts); err != nil {
-		return fmt.Errorf("invalid note name: %w", err)
+	if err := NewValidator().Run(opts); err != nil {
+		return fmt.Errorf("invalid note: %w", err)
+	}
+
+	content, err := readNoteContent(opts)
+	if err != nil {
+		return fmt.Errorf("failed to read note content: %w", err)
+	}
+	opts.content = content
+
+	if opts.useEditor && len(bytes.TrimSpace(opts.content)) == 0 {
+		root.AppLogger.Info("Editor buffer was empty; note not saved")
+		fmt.Println("Note is empty; nothing was saved")
+		return nil
 	}
 
-	if err := createAndSaveNote(opts.noteName, opts.notesDir); err != nil {
+	if err := createAndSaveNote(opts.folder, opts.noteName, opts.notesDir, opts.content, opts.tags); err != nil {
 		return fmt.Errorf("failed to create note %s: %w", opts.noteName, err)
 	}
 
 	return nil
 }
 
-func createAndSaveNote(noteName, notesDirPath string) error {
+// readNoteContent returns the note's initial content from, in order of
+// preference, the --message flag, an editor session, or piped stdin.
+func readNoteContent(opts *NewOptions) ([]byte, error) {
+	switch {
+	case opts.message != "":
+		root.AppLogger.Info("Using note content from --message flag")
+		return []byte(opts.message), nil
+
+	case opts.useEditor:
+		root.AppLogger.Info("Reading note content from editor")
+		return readFromEditor()
+
+	// Only read stdin when it is a pipe or redirected file: an open but idle
+	// stdin, as under CI or a non-interactive ssh session, would never end.
+	case terminal.IsPipeOrFile(os.Stdin):
+		root.AppLogger.Info("Reading note content from stdin")
+		return io.ReadAll(os.Stdin)
+
+	default:
+		return nil, nil
+	}
+}
+
+// readFromEditor opens the user's editor on a temporary buffer and returns
+// whatever was saved to it.
+func readFromEditor() ([]byte, error) {
+	buffer, err := os.CreateTemp("", editBufferPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create editor buffer: %w", err)
+	}
+	bufferPath := buffer.Name()
+	buffer.Close()
+	defer os.Remove(bufferPath)
+
+	if err := editor.Open(bufferPath); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(bufferPath)
+}
+
+func createAndSaveNote(folder, noteName, notesDirPath string, content []byte, tags []string) error {
 	root.AppLogger.Start(fmt.Sprintf("Creating note '%s' in directory %s...", noteName, notesDirPath))
 
-	fullNoteName := noteName + "_" + time.Now().Format(dateTimeFormat) + ".txt"
-	notePath := filepath.Join(notesDirPath, fullNoteName)
+	now := time.Now()
+	fullNoteName := path.Join(folder, file.NoteFileName(noteName, now))
+	notePath := filepath.Join(notesDirPath, filepath.FromSlash(fullNoteName))
+
+	if err := os.MkdirAll(filepath.Dir(notePath), folderPermissions); err != nil {
+		errMsg := fmt.Sprintf("failed to create folder %q: %v", folder, err)
+		root.AppLogger.Fail(errMsg)
+		return errors.New(errMsg)
+	}
 
 	// Check if note already exists
 	if _, err := os.Stat(notePath); err == nil {
@@ -82,17 +173,38 @@ func createAndSaveNote(noteName, notesDirPath string) error {
 		return errors.New(errMsg)
 	}
 
+	id, err := file.NewID()
+	if err != nil {
+		root.AppLogger.Fail(err.Error())
+		return err
+	}
+
+	frontMatter := file.FrontMatter{
+		ID:      id,
+		Title:   noteName,
+		Created: now.Truncate(time.Second),
+		Tags:    tags,
+	}
+
 	// Create note
-	file, err := os.Create(notePath)
+	noteFile, err := os.OpenFile(notePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, notePermissions)
 	if err != nil {
 		errMsg := fmt.Sprintf("failed to create file: %v", err)
 		root.AppLogger.Fail(errMsg)
 		return errors.New(errMsg)
 	}
-	defer file.Close()
+	defer noteFile.Close()
+
+	if _, err := noteFile.Write(append(frontMatter.Marshal(), content...)); err != nil {
+		errMsg := fmt.Sprintf("failed to write note content: %v", err)
+		root.AppLogger.Fail(errMsg)
+		return errors.New(errMsg)
+	}
 
 	successMsg := fmt.Sprintf("note created at: %s", notePath)
 	root.AppLogger.Success(successMsg)
 	fmt.Printf("Created note: %s\n", fullNoteName)
+
+	root.RecordChange(fmt.Sprintf("Create %s", fullNoteName), notePath)
 	return nil
 }
@@ -1,6 +1,11 @@
 package new
 
 type NewOptions struct {
-	noteName string
-	notesDir string
+	noteName  string
+	folder    string
+	notesDir  string
+	message   string
+	useEditor bool
+	content   []byte
+	tags      []string
 }
@@ -1,11 +1,10 @@
 package new
 
 import (
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
 	"github.com/rhysmah/note-app/validator"
 )
 
@@ -13,43 +12,41 @@ func NewValidator() *validator.Validator[NewOptions] {
 	return &validator.Validator[NewOptions]{
 		Rules: []validator.ValidationRule[NewOptions]{
 			validateNoteName,
+			validateTags,
 		},
 	}
 }
 
+// validateNoteName splits off any folder given with the name, as in
+// 'projects/alpha/kickoff', and checks both parts.
 func validateNoteName(opts *NewOptions) error {
 	root.AppLogger.Start(fmt.Sprintf("Validating note name: '%s'", opts.noteName))
 
-	noteNameTrimmed := strings.TrimSpace(opts.noteName)
+	opts.folder, opts.noteName = file.SplitNotePath(opts.noteName)
 
-	if len(noteNameTrimmed) > noteNameCharLimit {
-		errMsg := fmt.Sprintf("name exceeds %d character limit", noteNameCharLimit)
-		root.AppLogger.Fail(errMsg)
-		return errors.New(errMsg)
+	if err := file.ValidateFolder(opts.folder); err != nil {
+		root.AppLogger.Fail(err.Error())
+		return err
 	}
 
-	if err := checkForIllegalCharacters(noteNameTrimmed); err != nil {
-		return fmt.Errorf("invalid characters in note name: %w", err)
+	if err := file.ValidateNoteName(opts.noteName, root.AppConfig.NoteNameCharLimit); err != nil {
+		root.AppLogger.Fail(err.Error())
+		return err
 	}
 
 	root.AppLogger.Success("Note name passed all validation checks")
 	return nil
 }
 
-func checkForIllegalCharacters(noteName string) error {
-	var illegalCharsFound []rune
-
-	for _, char := range noteName {
-		if strings.ContainsRune(illegalChars, char) {
-			illegalCharsFound = append(illegalCharsFound, char)
-		}
-	}
-
-	if len(illegalCharsFound) > 0 {
-		errMsg := fmt.Sprintf("name contains illegal characters: %q", string(illegalCharsFound))
-		root.AppLogger.Fail(errMsg)
-		return errors.New(errMsg)
+// validateTags normalizes the tags given with --tag, rejecting any that
+// can't be stored in front matter.
+func validateTags(opts *NewOptions) error {
+	tags, err := file.NormalizeTags(opts.tags)
+	if err != nil {
+		root.AppLogger.Fail(err.Error())
+		return fmt.Errorf("invalid tag: %w", err)
 	}
 
+	opts.tags = tags
 	return nil
 }
@@ -0,0 +1,173 @@
+package notebook
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	appnotebook "github.com/rhysmah/note-app/internal/notebook"
+	"github.com/spf13/cobra"
+)
+
+const (
+	notebookCmd      = "notebook"
+	notebookCmdShort = "Create, list and switch between notebooks"
+	notebookCmdDesc  = `A notebook is a named notes directory, such as 'work' or 'personal'.
+Commands use the notebook selected with 'notebook use', or the one given with
+the global --notebook/-n flag. The 'default' notebook is the notes directory
+from the config file, or ~/notes.
+Example: note-app notebook create work && note-app -n work create standup`
+
+	pathFlag = "path"
+)
+
+func init() {
+	newNotebookCommand := NewNotebookCommand()
+	root.RootCmd.AddCommand(newNotebookCommand)
+}
+
+// NewNotebookCommand creates the notebook command and its create, list, use
+// and remove subcommands.
+func NewNotebookCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   notebookCmd,
+		Short: notebookCmdShort,
+		Long:  notebookCmdDesc,
+	}
+
+	cmd.AddCommand(
+		newCreateCommand(),
+		newListCommand(),
+		newUseCommand(),
+		newRemoveCommand(),
+	)
+	return cmd
+}
+
+func newCreateCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create a notebook",
+		Long: `Create a notebook stored in ~/notebooks/<name>, or in the directory given
+with --path. An existing directory can be registered as a notebook this way.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			root.AppLogger.Start(fmt.Sprintf("Creating notebook %q", name))
+
+			dir := root.DirManager.NotebookDir(name)
+			if path != "" {
+				absPath, err := root.DirManager.AbsolutePath(path)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %w", pathFlag, path, err)
+				}
+				dir = absPath
+			}
+
+			if err := root.Notebooks.Add(name, dir); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to create notebook %q: %v", name, err))
+				return err
+			}
+			if err := root.DirManager.CreateDir(dir); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+			if err := root.Notebooks.Save(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.Success(fmt.Sprintf("Notebook %q created at %q", name, dir))
+			fmt.Printf("Created notebook %s at %s\n", name, dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, pathFlag, "", "Directory to store the notebook in")
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List notebooks, marking the one in use",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defaultDir, err := root.DirManager.DefaultNotebookDir()
+			if err != nil {
+				return err
+			}
+
+			active := root.ActiveNotebook()
+			notebooks := append(
+				[]appnotebook.Notebook{{Name: appnotebook.DefaultName, Dir: defaultDir}},
+				root.Notebooks.List()...,
+			)
+
+			for _, nb := range notebooks {
+				marker := " "
+				if nb.Name == active {
+					marker = "*"
+				}
+				fmt.Printf("%s %-15s  %s\n", marker, nb.Name, nb.Dir)
+			}
+
+			if active == "" {
+				fmt.Printf("\nUsing %s from %s\n", root.DirManager.NotesDir(), root.DirManager.NotesDirSource())
+			}
+			return nil
+		},
+	}
+}
+
+func newUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use [name]",
+		Short: "Select the notebook commands operate on",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			root.AppLogger.Start(fmt.Sprintf("Switching to notebook %q", name))
+
+			if err := root.Notebooks.Use(name); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to switch to notebook %q: %v", name, err))
+				return err
+			}
+			if err := root.Notebooks.Save(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.Success(fmt.Sprintf("Now using notebook %q", name))
+			fmt.Printf("Now using notebook %s\n", name)
+			return nil
+		},
+	}
+}
+
+func newRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [name]",
+		Short: "Forget a notebook, leaving its notes on disk",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			root.AppLogger.Start(fmt.Sprintf("Removing notebook %q", name))
+
+			dir := root.Notebooks.Notebooks[name]
+			if err := root.Notebooks.Remove(name); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to remove notebook %q: %v", name, err))
+				return err
+			}
+			if err := root.Notebooks.Save(); err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			root.AppLogger.Success(fmt.Sprintf("Notebook %q removed", name))
+			fmt.Printf("Removed notebook %s; its notes are still in %s\n", name, dir)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,192 @@
+package rename
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/spf13/cobra"
+)
+
+const (
+	renameCmd      = "rename"
+	renameCmdShort = "Rename a note, keeping its creation date"
+	renameCmdDesc  = `Rename a note.
+The new name follows the same rules as 'create'. The note keeps its original
+creation timestamp, ID and tags, and wiki-style links to it in other notes
+([[old-name]] or [[old-name|label]]) are updated to the new name, unless
+other notes share the old name. The new name must not be in use already.
+Example: note-app rename meeting standup`
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	notePermissions = 0644
+)
+
+func init() {
+	newRenameCommand := NewRenameCommand()
+	root.RootCmd.AddCommand(newRenameCommand)
+}
+
+func NewRenameCommand() *cobra.Command {
+	renameCmdOpts := &RenameOptions{}
+
+	cmd := &cobra.Command{
+		Use:   renameCmd + " [note] [new-name]",
+		Short: renameCmdShort,
+		Long:  renameCmdDesc,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Renaming note %q to %q", args[0], args[1]))
+
+			renameCmdOpts.notesDir = root.DirManager.NotesDir()
+			renameCmdOpts.noteName = args[0]
+			renameCmdOpts.newName = args[1]
+
+			if err := renameNote(renameCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to rename %q: %v", renameCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Note rename completed successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func renameNote(opts *RenameOptions) error {
+	if err := file.ValidateNoteName(opts.newName, root.AppConfig.NoteNameCharLimit); err != nil {
+		return fmt.Errorf("invalid note name: %w", err)
+	}
+
+	files, err := file.PrepareNoteFiles(root.AppLogger, opts.notesDir)
+	if err != nil {
+		return err
+	}
+
+	note, err := file.ResolveNote(files, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	oldDisplayName := file.DisplayName(note.Name)
+	newFileName := file.RenamedFileName(note.Name, opts.newName, note.DateCreated)
+	newPath := filepath.Join(filepath.Dir(note.FilePath), newFileName)
+
+	if newFileName == note.Name {
+		return errors.New("the note already has that name")
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("note %q already exists", newFileName)
+	}
+	// Links name notes without their folder or timestamp, so a second note
+	// with the new name would make [[new-name]] links ambiguous.
+	if existing := notesNamed(files, opts.newName); len(existing) > 0 {
+		return fmt.Errorf("a note named %q already exists (%s); choose another name", opts.newName, existing[0].RelPath())
+	}
+
+	frontMatter, body, err := file.ReadNoteForUpdate(note)
+	if err != nil {
+		return err
+	}
+	if frontMatter.Title == oldDisplayName {
+		frontMatter.Title = opts.newName
+	}
+
+	if err := os.Rename(note.FilePath, newPath); err != nil {
+		return fmt.Errorf("failed to rename note file: %w", err)
+	}
+	if err := file.WriteNote(newPath, frontMatter, body); err != nil {
+		return err
+	}
+	// A rename isn't an edit, so keep the note's place when sorting by modification date.
+	if err := os.Chtimes(newPath, time.Now(), note.DateModified); err != nil {
+		return fmt.Errorf("failed to restore modification time: %w", err)
+	}
+
+	root.AppLogger.Success(fmt.Sprintf("Renamed %q to %q", note.Name, newFileName))
+	fmt.Printf("Renamed note: %s -> %s\n", note.Name, newFileName)
+
+	replacements := map[string]string{note.Name: newFileName}
+	if namesakes := notesNamed(files, oldDisplayName); len(namesakes) > 1 {
+		// [[old-name]] may mean any of them, so leave those links alone.
+		root.AppLogger.Info(fmt.Sprintf("%d notes are named %q; not updating [[%s]] links", len(namesakes), oldDisplayName, oldDisplayName))
+		fmt.Fprintf(os.Stderr, "Warning: %d notes are named %q, so [[%s]] links were left unchanged\n",
+			len(namesakes), oldDisplayName, oldDisplayName)
+	} else {
+		replacements[oldDisplayName] = opts.newName
+	}
+
+	updated, err := updateLinks(files, note.FilePath, replacements)
+	if err != nil {
+		return fmt.Errorf("note renamed, but updating links failed: %w", err)
+	}
+	if len(updated) > 0 {
+		fmt.Printf("Updated links in %d other notes\n", len(updated))
+	}
+
+	changed := append([]string{note.FilePath, newPath}, updated...)
+	root.RecordChange(fmt.Sprintf("Rename %s to %s", note.Name, newFileName), changed...)
+	return nil
+}
+
+// notesNamed returns the notes whose display name is name, in any folder.
+func notesNamed(files []file.File, name string) []file.File {
+	var named []file.File
+	for _, f := range files {
+		if file.DisplayName(f.Name) == name {
+			named = append(named, f)
+		}
+	}
+	return named
+}
+
+// updateLinks rewrites [[old]] and [[old|label]] links in every note except
+// the renamed one, returning the paths of the notes that changed.
+func updateLinks(files []file.File, renamedPath string, replacements map[string]string) ([]string, error) {
+	type linkRewrite struct {
+		pattern *regexp.Regexp
+		target  string
+	}
+
+	var rewrites []linkRewrite
+	for oldName, newName := range replacements {
+		rewrites = append(rewrites, linkRewrite{
+			pattern: regexp.MustCompile(`\[\[` + regexp.QuoteMeta(oldName) + `(\|[^\]]*)?\]\]`),
+			target:  "[[" + newName + "$1]]",
+		})
+	}
+
+	var updated []string
+	for _, f := range files {
+		if f.FilePath == renamedPath {
+			continue
+		}
+
+		content, err := os.ReadFile(f.FilePath)
+		if err != nil {
+			return updated, fmt.Errorf("failed to read %q: %w", f.Name, err)
+		}
+
+		rewritten := content
+		for _, rewrite := range rewrites {
+			rewritten = rewrite.pattern.ReplaceAll(rewritten, []byte(rewrite.target))
+		}
+		if string(rewritten) == string(content) {
+			continue
+		}
+
+		if err := os.WriteFile(f.FilePath, rewritten, notePermissions); err != nil {
+			return updated, fmt.Errorf("failed to update links in %q: %w", f.Name, err)
+		}
+		root.AppLogger.Info(fmt.Sprintf("Updated links in %q", f.Name))
+		updated = append(updated, f.FilePath)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,7 @@
+package rename
+
+type RenameOptions struct {
+	noteName string
+	newName  string
+	notesDir string
+}
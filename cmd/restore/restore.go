@@ -0,0 +1,68 @@
+package restore
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/internal/trash"
+	"github.com/spf13/cobra"
+)
+
+const (
+	restoreCmd      = "restore"
+	restoreCmdShort = "Restore a deleted note from the trash"
+	restoreCmdDesc  = `Move a deleted note out of the trash and back to where it was.
+The note can be given by its file name, the name it was created with, or its
+number in 'trash list'.
+Example: note-app restore meeting`
+)
+
+func init() {
+	newRestoreCommand := NewRestoreCommand()
+	root.RootCmd.AddCommand(newRestoreCommand)
+}
+
+func NewRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   restoreCmd + " [note]",
+		Short: restoreCmdShort,
+		Long:  restoreCmdDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Restoring note %q from trash", args[0]))
+
+			if err := restoreNote(args[0]); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to restore %q: %v", args[0], err))
+				return err
+			}
+
+			root.AppLogger.End("Note restored successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func restoreNote(query string) error {
+	bin := trash.New(root.DirManager.TrashDir())
+
+	entries, err := bin.List()
+	if err != nil {
+		return err
+	}
+
+	entry, err := trash.Resolve(entries, query)
+	if err != nil {
+		return err
+	}
+
+	if err := bin.Restore(entry); err != nil {
+		return err
+	}
+
+	root.AppLogger.Success(fmt.Sprintf("Restored %q to %q", entry.Name(), entry.OriginalPath))
+	fmt.Printf("Restored note: %s\n", entry.OriginalPath)
+
+	root.RecordChange(fmt.Sprintf("Restore %s from trash", entry.Name()), entry.OriginalPath)
+	return nil
+}
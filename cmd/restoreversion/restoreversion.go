@@ -0,0 +1,101 @@
+package restoreversion
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/versions"
+	"github.com/spf13/cobra"
+)
+
+const (
+	restoreVersionCmd      = "restore-version"
+	restoreVersionCmdShort = "Restore a note to a saved version"
+	restoreVersionCmdDesc  = `Replace a note's content with one of its saved versions.
+The version is given by number (3 or v3) or hash prefix, as shown by 'versions'.
+The note's current content is saved as a version first, so a restore can be undone.
+Example: note-app restore-version meeting v3`
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	notePermissions = 0644
+)
+
+func init() {
+	newRestoreVersionCommand := NewRestoreVersionCommand()
+	root.RootCmd.AddCommand(newRestoreVersionCommand)
+}
+
+func NewRestoreVersionCommand() *cobra.Command {
+	restoreVersionCmdOpts := &RestoreVersionOptions{}
+
+	cmd := &cobra.Command{
+		Use:   restoreVersionCmd + " [note] [version]",
+		Short: restoreVersionCmdShort,
+		Long:  restoreVersionCmdDesc,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Restoring note %q to version %q", args[0], args[1]))
+
+			restoreVersionCmdOpts.notesDir = root.DirManager.NotesDir()
+			restoreVersionCmdOpts.noteName = args[0]
+			restoreVersionCmdOpts.version = args[1]
+
+			if err := restoreVersion(restoreVersionCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to restore %q: %v", restoreVersionCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Version restored successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func restoreVersion(opts *RestoreVersionOptions) error {
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	store := versions.New(root.DirManager.VersionsDir())
+	noteVersions, err := store.List(note.ID)
+	if err != nil {
+		return err
+	}
+
+	version, err := versions.Resolve(noteVersions, opts.version)
+	if err != nil {
+		return err
+	}
+
+	content, err := store.Content(version)
+	if err != nil {
+		return err
+	}
+
+	current, err := os.ReadFile(note.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+	if string(current) == string(content) {
+		fmt.Printf("%s already matches v%d\n", note.Name, version.Number)
+		return nil
+	}
+
+	root.SnapshotNote(note, current)
+
+	if err := os.WriteFile(note.FilePath, content, notePermissions); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+
+	root.SnapshotNote(note, content)
+
+	root.AppLogger.Success(fmt.Sprintf("Restored %q to version %d", note.Name, version.Number))
+	fmt.Printf("Restored %s to v%d\n", note.Name, version.Number)
+
+	root.RecordChange(fmt.Sprintf("Restore %s to v%d", note.Name, version.Number), note.FilePath)
+	return nil
+}
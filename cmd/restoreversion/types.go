@@ -0,0 +1,7 @@
+package restoreversion
+
+type RestoreVersionOptions struct {
+	noteName string
+	version  string
+	notesDir string
+}
@@ -0,0 +1,118 @@
+package revert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/gitrepo"
+	"github.com/spf13/cobra"
+)
+
+const (
+	revertCmd      = "revert"
+	revertCmdShort = "Restore a note to a past version"
+	revertCmdDesc  = `Replace a note's content with a version recorded in its history.
+The revision is a commit hash, or a unique prefix of one, as shown by 'history'.
+The revert is itself recorded, so it can be undone the same way.
+Requires the history.git setting to be enabled.
+Example: note-app revert meeting 3f2a9c1d`
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	notePermissions = 0644
+)
+
+func init() {
+	newRevertCommand := NewRevertCommand()
+	root.RootCmd.AddCommand(newRevertCommand)
+}
+
+func NewRevertCommand() *cobra.Command {
+	revertCmdOpts := &RevertOptions{}
+
+	cmd := &cobra.Command{
+		Use:   revertCmd + " [note] [revision]",
+		Short: revertCmdShort,
+		Long:  revertCmdDesc,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Reverting note %q to %q", args[0], args[1]))
+
+			revertCmdOpts.notesDir = root.DirManager.NotesDir()
+			revertCmdOpts.noteName = args[0]
+			revertCmdOpts.revision = args[1]
+
+			if err := revertNote(revertCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to revert %q: %v", revertCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Note reverted successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func revertNote(opts *RevertOptions) error {
+	repo, err := root.OpenHistoryRepo()
+	if err != nil {
+		return err
+	}
+
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := root.NoteRevisions(repo, note)
+	if err != nil {
+		return err
+	}
+
+	rev, err := findRevision(revisions, opts.revision)
+	if err != nil {
+		return err
+	}
+
+	content, err := repo.Show(rev.Hash, rev.Path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(note.FilePath, content, notePermissions); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+
+	root.AppLogger.Success(fmt.Sprintf("Reverted %q to %s", note.Name, rev.Hash))
+	fmt.Printf("Reverted %s to %s (%s)\n", note.Name, rev.ShortHash(), rev.Subject)
+
+	root.RecordChange(fmt.Sprintf("Revert %s to %s", note.Name, rev.ShortHash()), note.FilePath)
+	return nil
+}
+
+// findRevision returns the single revision whose hash starts with prefix.
+func findRevision(revisions []gitrepo.Revision, prefix string) (gitrepo.Revision, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return gitrepo.Revision{}, fmt.Errorf("revision must not be empty")
+	}
+
+	var matches []gitrepo.Revision
+	for _, rev := range revisions {
+		if strings.HasPrefix(rev.Hash, prefix) {
+			matches = append(matches, rev)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return gitrepo.Revision{}, fmt.Errorf("no revision %q in this note's history; see 'note-app history'", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return gitrepo.Revision{}, fmt.Errorf("revision %q is ambiguous; use more characters", prefix)
+	}
+}
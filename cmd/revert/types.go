@@ -0,0 +1,7 @@
+package revert
+
+type RevertOptions struct {
+	noteName string
+	revision string
+	notesDir string
+}
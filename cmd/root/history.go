@@ -0,0 +1,74 @@
+package root
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/config"
+	"github.com/rhysmah/note-app/internal/gitrepo"
+)
+
+// RecordChange commits the notes at paths, the absolute paths of every file
+// a command created, changed or removed, to the notes directory's git
+// repository when the history.git setting is enabled. Commands call it after
+// every change to a note. A failure to commit is logged and reported as a
+// warning rather than failing the command, since the change itself succeeded.
+func RecordChange(message string, paths ...string) {
+	if AppConfig == nil || !AppConfig.HistoryGit {
+		return
+	}
+
+	AppLogger.Start(fmt.Sprintf("Recording change in git: %q", message))
+
+	repo, err := gitrepo.Open(DirManager.NotesDir())
+	if err != nil {
+		AppLogger.Fail(fmt.Sprintf("Failed to open git repository: %v", err))
+		fmt.Fprintf(os.Stderr, "Warning: change not recorded in history: %v\n", err)
+		return
+	}
+
+	relPaths := make([]string, 0, len(paths))
+	for _, p := range paths {
+		relPath, err := filepath.Rel(DirManager.NotesDir(), p)
+		if err != nil {
+			AppLogger.Fail(fmt.Sprintf("Failed to locate %q in notes directory: %v", p, err))
+			fmt.Fprintf(os.Stderr, "Warning: change not recorded in history: %v\n", err)
+			return
+		}
+		relPaths = append(relPaths, relPath)
+	}
+
+	committed, err := repo.Commit(message, relPaths...)
+	if err != nil {
+		AppLogger.Fail(fmt.Sprintf("Failed to record change: %v", err))
+		fmt.Fprintf(os.Stderr, "Warning: change not recorded in history: %v\n", err)
+		return
+	}
+
+	if committed {
+		AppLogger.Success("Change recorded in git")
+	} else {
+		AppLogger.Info("No changes to record in git")
+	}
+}
+
+// OpenHistoryRepo returns the git repository in the notes directory, or an
+// error explaining how to turn history on if it is disabled.
+func OpenHistoryRepo() (*gitrepo.Repo, error) {
+	if AppConfig == nil || !AppConfig.HistoryGit {
+		return nil, errors.New("git history is disabled; enable it with 'note-app config set " + config.KeyHistoryGit + " true'")
+	}
+	return gitrepo.Open(DirManager.NotesDir())
+}
+
+// NoteRevisions returns the recorded versions of note, newest first.
+func NoteRevisions(repo *gitrepo.Repo, note *file.File) ([]gitrepo.Revision, error) {
+	relPath, err := filepath.Rel(DirManager.NotesDir(), note.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate note in notes directory: %w", err)
+	}
+	return repo.Log(relPath)
+}
@@ -4,18 +4,51 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/rhysmah/note-app/internal/config"
 	"github.com/rhysmah/note-app/internal/filesystem"
 	"github.com/rhysmah/note-app/internal/logger"
+	"github.com/rhysmah/note-app/internal/notebook"
+	"github.com/rhysmah/note-app/internal/output"
 	"github.com/spf13/cobra"
 )
 
+const (
+	notesDirFlag = "notes-dir"
+
+	notebookFlag      = "notebook"
+	notebookFlagShort = "n"
+
+	outputFlag      = "output"
+	outputFlagShort = "O"
+
+	// configCmdName is the command used to repair a broken config file.
+	configCmdName = "config"
+	// notebookCmdName is the command used to select another notebook when
+	// the one in use has gone missing.
+	notebookCmdName = "notebook"
+)
+
 var (
 	AppLogger      *logger.Logger
+	AppConfig      *config.Config
+	Notebooks      *notebook.Registry
 	DirManager     *filesystem.DirectoryManager
 	UserDirectory  string
 	NotesDirectory string
+	NotebookName   string
+	OutputFormat   string
+	Output         output.Format
 )
 
+func init() {
+	RootCmd.PersistentFlags().StringVar(&NotesDirectory, notesDirFlag, "",
+		fmt.Sprintf("Notes directory (overrides $%s, the notebook in use and the config file)", filesystem.NotesDirEnvVar))
+	RootCmd.PersistentFlags().StringVarP(&NotebookName, notebookFlag, notebookFlagShort, "",
+		"Notebook to use instead of the one selected with 'notebook use'")
+	RootCmd.PersistentFlags().StringVarP(&OutputFormat, outputFlag, outputFlagShort, string(output.FormatTable),
+		fmt.Sprintf("Output format for listed notes: %s", output.AvailableFormats()))
+}
+
 var RootCmd = &cobra.Command{
 	Use: "note-app",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
@@ -27,9 +60,37 @@ var RootCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		DirManager, err = filesystem.NewDirectoryManager(AppLogger)
+		Output, err = output.ParseFormat(OutputFormat)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		AppConfig, err = config.Load(AppLogger)
+		if err != nil {
+			// The config commands must still work so the file can be fixed.
+			if AppConfig == nil || !isSubcommandOf(cmd, configCmdName) {
+				fmt.Printf("Failed to load config: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v\nInvalid settings use their defaults and are removed the next time a setting is changed.\n", err)
+		}
+
+		Notebooks, err = notebook.Load(AppLogger)
+		if err != nil {
+			fmt.Printf("Failed to load notebooks: %v\n", err)
+			os.Exit(1)
+		}
+
+		overrides, err := notesDirOverrides(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		DirManager, err = filesystem.NewDirectoryManager(AppLogger, overrides)
 		if err != nil {
-			fmt.Printf("Failed to initialize logger: %v", err)
+			fmt.Printf("Failed to initialize directory manager: %v\n", err)
 			os.Exit(1)
 		}
 	},
@@ -41,6 +102,70 @@ var RootCmd = &cobra.Command{
 	},
 }
 
+// isSubcommandOf reports whether cmd is the top-level command called name
+// or one of its subcommands.
+func isSubcommandOf(cmd *cobra.Command, name string) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if !c.Parent().HasParent() {
+			return c.Name() == name
+		}
+	}
+	return false
+}
+
+// notesDirOverrides collects the notes directory locations given by flags,
+// the notebook registry and the config file for cmd.
+func notesDirOverrides(cmd *cobra.Command) (filesystem.NotesDirOverrides, error) {
+	overrides := filesystem.NotesDirOverrides{
+		Flag:   NotesDirectory,
+		Config: AppConfig.NotesDir,
+	}
+
+	if NotesDirectory != "" && NotebookName != "" {
+		return overrides, fmt.Errorf("--%s and --%s cannot be used together", notesDirFlag, notebookFlag)
+	}
+
+	if NotebookName != "" && NotebookName != notebook.DefaultName {
+		dir, err := Notebooks.Lookup(NotebookName)
+		if err != nil {
+			return overrides, err
+		}
+		overrides.NotebookFlag = dir
+	}
+
+	// --notebook default skips the notebook in use.
+	if Notebooks.Current != "" && NotebookName == "" {
+		dir, err := Notebooks.Lookup(Notebooks.Current)
+		switch {
+		case err == nil:
+			overrides.Notebook = dir
+		case isSubcommandOf(cmd, notebookCmdName):
+			// The notebook commands must still work so another can be selected.
+			AppLogger.Info(fmt.Sprintf("Notebook in use is unavailable (%v); using the default notebook", err))
+			fmt.Fprintf(os.Stderr, "Warning: notebook in use: %v\nUsing the %s notebook until another is selected.\n", err, notebook.DefaultName)
+		default:
+			return overrides, fmt.Errorf("notebook in use: %w", err)
+		}
+	}
+
+	return overrides, nil
+}
+
+// ActiveNotebook returns the name of the notebook commands are operating on,
+// or an empty string if the notes directory was given some other way.
+func ActiveNotebook() string {
+	switch DirManager.NotesDirSource() {
+	case filesystem.SourceNotebookFlag:
+		return NotebookName
+	case filesystem.SourceNotebook:
+		return Notebooks.Current
+	case filesystem.SourceConfig, filesystem.SourceDefault:
+		return notebook.DefaultName
+	default:
+		return ""
+	}
+}
+
 func Execute() {
 	err := RootCmd.Execute()
 	if err != nil {
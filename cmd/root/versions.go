@@ -0,0 +1,41 @@
+package root
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/versions"
+)
+
+// SnapshotNote saves content as the newest version of note and prunes old
+// versions beyond the versions.keep setting. Like RecordChange, a failure is
+// logged and reported as a warning rather than failing the command.
+func SnapshotNote(note *file.File, content []byte) {
+	if AppConfig == nil || AppConfig.VersionsKeep == 0 {
+		return
+	}
+
+	store := versions.New(DirManager.VersionsDir())
+
+	version, created, err := store.Snapshot(note.ID, note.Name, content)
+	if err != nil {
+		AppLogger.Fail(fmt.Sprintf("Failed to snapshot %q: %v", note.Name, err))
+		fmt.Fprintf(os.Stderr, "Warning: version of %s not saved: %v\n", note.Name, err)
+		return
+	}
+	if !created {
+		AppLogger.Info(fmt.Sprintf("Version %d of %q is unchanged; no snapshot needed", version.Number, note.Name))
+		return
+	}
+	AppLogger.Success(fmt.Sprintf("Saved version %d of %q", version.Number, note.Name))
+
+	dropped, err := store.Prune(note.ID, AppConfig.VersionsKeep)
+	if err != nil {
+		AppLogger.Fail(fmt.Sprintf("Failed to prune versions of %q: %v", note.Name, err))
+		return
+	}
+	if dropped > 0 {
+		AppLogger.Info(fmt.Sprintf("Pruned %d old versions of %q", dropped, note.Name))
+	}
+}
@@ -0,0 +1,250 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/filesystem"
+	"github.com/rhysmah/note-app/internal/index"
+	"github.com/rhysmah/note-app/internal/logger"
+	"github.com/rhysmah/note-app/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	regexCmd = "regex"
+
+	ignoreCaseCmd      = "ignore-case"
+	ignoreCaseCmdShort = "i"
+
+	contextCmd      = "context"
+	contextCmdShort = "C"
+
+	rankedCmd      = "ranked"
+	rankedCmdShort = "r"
+
+	searchDesc = `Search the contents of every note for a pattern.
+Matches are grouped by note and shown with their line numbers.
+Example: note-app search -i --context 2 "quarterly review"
+
+With --ranked, the persistent search index is used instead and notes are
+listed by relevance. Ranked queries are case-insensitive and support terms,
+"quoted phrases" and prefix* matches; every part must match.
+Example: note-app search --ranked '"quarterly review" budg*'`
+
+	// ANSI escape codes used to highlight matches on a terminal.
+	highlightStart = "\033[1;31m"
+	highlightEnd   = "\033[0m"
+	noteNameStart  = "\033[1;35m"
+)
+
+// init registers the search command and its flags with the root command.
+func init() {
+	newSearchCommand := NewSearchCommand()
+	root.RootCmd.AddCommand(newSearchCommand)
+}
+
+// NewSearchCommand creates and returns a new cobra.Command for full-text search.
+func NewSearchCommand() *cobra.Command {
+	searchCmd := &SearchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search [pattern]",
+		Short: "Search note contents",
+		Args:  cobra.ExactArgs(1),
+		Long:  searchDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			searchCmd.Pattern = args[0]
+			searchCmd.Highlight = terminal.IsTerminal(os.Stdout)
+
+			return searchCmd.Run(root.AppLogger, root.DirManager)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&searchCmd.Regex, regexCmd, false, "Treat the pattern as a regular expression")
+	flags.BoolVarP(&searchCmd.IgnoreCase, ignoreCaseCmd, ignoreCaseCmdShort, false, "Ignore case when matching")
+	flags.IntVarP(&searchCmd.ContextLines, contextCmd, contextCmdShort, 0, "Number of context lines to show around each match")
+	flags.BoolVarP(&searchCmd.Ranked, rankedCmd, rankedCmdShort, false, "Use the search index and rank notes by relevance")
+
+	return cmd
+}
+
+// Run executes the search command with the specified options.
+func (opts *SearchOptions) Run(logger *logger.Logger, dm *filesystem.DirectoryManager) error {
+	logger.Start(fmt.Sprintf("Searching notes for %q", opts.Pattern))
+
+	if err := opts.validate(); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	files, err := file.PrepareNoteFiles(logger, dm.NotesDir())
+	if err != nil {
+		return fmt.Errorf("failed to get files: %w", err)
+	}
+	opts.files = files
+
+	if opts.Ranked {
+		if err := opts.executeRanked(logger, dm, os.Stdout); err != nil {
+			return fmt.Errorf("could not execute command: %w", err)
+		}
+		logger.End("Ranked search completed")
+		return nil
+	}
+
+	if err := opts.execute(logger, os.Stdout); err != nil {
+		return fmt.Errorf("could not execute command: %w", err)
+	}
+
+	logger.End("Search completed")
+	return nil
+}
+
+// validate checks if the provided options meet all validation rules.
+func (opts *SearchOptions) validate() error {
+	v := NewValidator()
+	return v.Run(opts)
+}
+
+// execute scans every note and writes grouped matches to out.
+func (opts *SearchOptions) execute(logger *logger.Logger, out io.Writer) error {
+	totalMatches := 0
+
+	for _, note := range opts.files {
+		matches, count, err := opts.searchNote(note)
+		if err != nil {
+			logger.Fail(fmt.Sprintf("Failed to search %q: %v", note.Name, err))
+			return err
+		}
+		if count == 0 {
+			continue
+		}
+
+		if totalMatches > 0 {
+			fmt.Fprintln(out)
+		}
+		opts.printMatches(out, matches)
+		totalMatches += count
+	}
+
+	if totalMatches == 0 {
+		fmt.Fprintf(out, "No matches found for %q\n", opts.Pattern)
+	}
+
+	logger.Success(fmt.Sprintf("Found %d matching lines", totalMatches))
+	return nil
+}
+
+// executeRanked brings the search index up to date, queries it, and writes
+// the matching notes to out ordered by relevance.
+func (opts *SearchOptions) executeRanked(logger *logger.Logger, dm *filesystem.DirectoryManager, out io.Writer) error {
+	idx, err := index.Load(logger, dm.IndexDir(), dm.NotesDir())
+	if err != nil {
+		return err
+	}
+
+	stats, err := idx.Update(logger, opts.files)
+	if err != nil {
+		return err
+	}
+	if stats.Added+stats.Updated+stats.Removed > 0 {
+		if err := idx.Save(); err != nil {
+			logger.Fail(err.Error())
+			return err
+		}
+	}
+
+	results, err := idx.Search(opts.Pattern)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(out, "No matches found for %q\n", opts.Pattern)
+		return nil
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(out, "%6.2f  %s\n", result.Score, result.Doc.Name)
+	}
+
+	logger.Success(fmt.Sprintf("Found %d matching notes", len(results)))
+	return nil
+}
+
+// searchNote returns the matching lines of a note's body along with their
+// context, and the number of lines that actually matched. Front matter is
+// not searched, and line numbers count from the first line of the body.
+func (opts *SearchOptions) searchNote(note file.File) (noteMatches, int, error) {
+	_, body, err := file.ReadNote(note.FilePath)
+	if err != nil {
+		return noteMatches{}, 0, err
+	}
+
+	text := strings.TrimSuffix(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+	lines := strings.Split(text, "\n")
+	result := noteMatches{note: note}
+
+	// Mark every line that should be printed, either as a match or as context.
+	include := make([]bool, len(lines))
+	isMatch := make([]bool, len(lines))
+	count := 0
+
+	for i, line := range lines {
+		if !opts.matcher.MatchString(line) {
+			continue
+		}
+		count++
+		isMatch[i] = true
+
+		start := max(0, i-opts.ContextLines)
+		end := min(len(lines)-1, i+opts.ContextLines)
+		for j := start; j <= end; j++ {
+			include[j] = true
+		}
+	}
+
+	for i, line := range lines {
+		if include[i] {
+			result.lines = append(result.lines, lineMatch{number: i + 1, text: line, isMatch: isMatch[i]})
+		}
+	}
+
+	return result, count, nil
+}
+
+// printMatches writes a note's name followed by its matching lines.
+// Matching lines use ':' after the line number and context lines use '-',
+// as grep does; '--' separates groups of lines that aren't adjacent.
+func (opts *SearchOptions) printMatches(out io.Writer, matches noteMatches) {
+	if opts.Highlight {
+		fmt.Fprintf(out, "%s%s%s\n", noteNameStart, matches.note.RelPath(), highlightEnd)
+	} else {
+		fmt.Fprintln(out, matches.note.RelPath())
+	}
+
+	previous := 0
+	for _, line := range matches.lines {
+		if previous != 0 && line.number != previous+1 {
+			fmt.Fprintln(out, "  --")
+		}
+		previous = line.number
+
+		separator := "-"
+		text := line.text
+		if line.isMatch {
+			separator = ":"
+			if opts.Highlight {
+				text = opts.matcher.ReplaceAllStringFunc(text, func(hit string) string {
+					return highlightStart + hit + highlightEnd
+				})
+			}
+		}
+
+		fmt.Fprintf(out, "  %4d%s %s\n", line.number, separator, text)
+	}
+}
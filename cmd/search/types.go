@@ -0,0 +1,32 @@
+package search
+
+import (
+	"regexp"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+type SearchOptions struct {
+	Pattern      string
+	Regex        bool
+	IgnoreCase   bool
+	ContextLines int
+	Ranked       bool
+	Highlight    bool
+	matcher      *regexp.Regexp
+	files        []file.File
+}
+
+// lineMatch is a single line of a note that either matched the pattern
+// or is shown as context around a match.
+type lineMatch struct {
+	number  int
+	text    string
+	isMatch bool
+}
+
+// noteMatches holds the lines to print for a note, in file order.
+type noteMatches struct {
+	note  file.File
+	lines []lineMatch
+}
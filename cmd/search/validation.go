@@ -0,0 +1,69 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/rhysmah/note-app/validator"
+)
+
+// NewValidator creates a validator with a predefined set of validation rules.
+func NewValidator() *validator.Validator[SearchOptions] {
+	return &validator.Validator[SearchOptions]{
+		Rules: []validator.ValidationRule[SearchOptions]{
+			validatePatternExists,
+			validateContextLines,
+			validateRankedFlags,
+			validatePatternCompiles,
+		},
+	}
+}
+
+// validatePatternExists checks that the pattern is not empty.
+func validatePatternExists(opts *SearchOptions) error {
+	if opts.Pattern == "" {
+		return fmt.Errorf("search pattern cannot be empty")
+	}
+	return nil
+}
+
+// validateContextLines ensures the number of context lines is not negative.
+func validateContextLines(opts *SearchOptions) error {
+	if opts.ContextLines < 0 {
+		return fmt.Errorf("%q must be zero or more, got %d", contextCmd, opts.ContextLines)
+	}
+	return nil
+}
+
+// validateRankedFlags rejects line-based flags that don't apply to ranked,
+// index-backed searches.
+func validateRankedFlags(opts *SearchOptions) error {
+	if !opts.Ranked {
+		return nil
+	}
+	if opts.Regex {
+		return fmt.Errorf("%q cannot be combined with %q", rankedCmd, regexCmd)
+	}
+	if opts.ContextLines > 0 {
+		return fmt.Errorf("%q cannot be combined with %q", rankedCmd, contextCmd)
+	}
+	return nil
+}
+
+// validatePatternCompiles builds the matcher, reporting invalid regular expressions.
+func validatePatternCompiles(opts *SearchOptions) error {
+	expr := opts.Pattern
+	if !opts.Regex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if opts.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+
+	matcher, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression %q: %w", opts.Pattern, err)
+	}
+	opts.matcher = matcher
+	return nil
+}
@@ -0,0 +1,113 @@
+package tag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/spf13/cobra"
+)
+
+const (
+	tagCmd      = "tag"
+	tagCmdShort = "Add or remove tags on a note"
+	tagCmdDesc  = `Add or remove tags on a note.
+Tags are stored in the note's front matter and can be used to filter 'list'.
+Tags are lower-cased and may contain letters, digits, '-', '_' and '/'.
+Example: note-app tag add meeting work planning`
+)
+
+func init() {
+	newTagCommand := NewTagCommand()
+	root.RootCmd.AddCommand(newTagCommand)
+}
+
+// NewTagCommand creates the tag command and its add and remove subcommands.
+func NewTagCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   tagCmd,
+		Short: tagCmdShort,
+		Long:  tagCmdDesc,
+	}
+
+	cmd.AddCommand(
+		newTagSubcommand("add", "Add tags to a note", addTags),
+		newTagSubcommand("remove", "Remove tags from a note", removeTags),
+	)
+	return cmd
+}
+
+func newTagSubcommand(use, short string, run func(*TagOptions) error) *cobra.Command {
+	opts := &TagOptions{}
+
+	return &cobra.Command{
+		Use:   use + " [note] [tag]...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Running 'tag %s' on note %q with tags %v", use, args[0], args[1:]))
+
+			opts.notesDir = root.DirManager.NotesDir()
+			opts.noteName = args[0]
+
+			tags, err := file.NormalizeTags(args[1:])
+			if err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+			opts.tags = tags
+
+			if err := run(opts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to update tags on %q: %v", opts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Tag update completed successfully")
+			return nil
+		},
+	}
+}
+
+func addTags(opts *TagOptions) error {
+	return updateTags(opts, func(fm *file.FrontMatter) []string {
+		return fm.AddTags(opts.tags...)
+	}, "Added")
+}
+
+func removeTags(opts *TagOptions) error {
+	return updateTags(opts, func(fm *file.FrontMatter) []string {
+		return fm.RemoveTags(opts.tags...)
+	}, "Removed")
+}
+
+// updateTags applies change to the note's front matter and rewrites the note
+// only if at least one tag was actually added or removed.
+func updateTags(opts *TagOptions, change func(*file.FrontMatter) []string, verb string) error {
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	frontMatter, body, err := file.ReadNoteForUpdate(note)
+	if err != nil {
+		return err
+	}
+
+	changed := change(&frontMatter)
+	if len(changed) == 0 {
+		root.AppLogger.Info(fmt.Sprintf("No tag changes needed for %q", note.Name))
+		fmt.Printf("No changes made to %s\n", note.Name)
+		return nil
+	}
+
+	if err := file.WriteNote(note.FilePath, frontMatter, body); err != nil {
+		return err
+	}
+
+	root.AppLogger.Success(fmt.Sprintf("%s tags %v on %q", verb, changed, note.Name))
+	fmt.Printf("%s tags on %s: %s\n", verb, note.Name, strings.Join(changed, ", "))
+
+	root.RecordChange(fmt.Sprintf("%s tags on %s: %s", verb, note.Name, strings.Join(changed, ", ")), note.FilePath)
+	return nil
+}
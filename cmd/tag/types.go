@@ -0,0 +1,7 @@
+package tag
+
+type TagOptions struct {
+	noteName string
+	notesDir string
+	tags     []string
+}
@@ -0,0 +1,108 @@
+package trash
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/internal/timeutil"
+	notetrash "github.com/rhysmah/note-app/internal/trash"
+	"github.com/spf13/cobra"
+)
+
+const (
+	trashCmd      = "trash"
+	trashCmdShort = "List or empty deleted notes"
+	trashCmdDesc  = `Deleted notes are moved to ~/.note-app/trash/ rather than removed.
+Use 'trash list' to see them, 'restore' to bring one back, and 'trash empty'
+to delete them permanently.
+Example: note-app trash empty --older-than 30d`
+
+	olderThanFlag = "older-than"
+
+	trashDateFormat = "2006-01-02 15:04"
+)
+
+func init() {
+	newTrashCommand := NewTrashCommand()
+	root.RootCmd.AddCommand(newTrashCommand)
+}
+
+// NewTrashCommand creates the trash command and its list and empty subcommands.
+func NewTrashCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   trashCmd,
+		Short: trashCmdShort,
+		Long:  trashCmdDesc,
+	}
+
+	cmd.AddCommand(newListCommand(), newEmptyCommand())
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List deleted notes, most recent first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bin := notetrash.New(root.DirManager.TrashDir())
+
+			entries, err := bin.List()
+			if err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			if len(entries) == 0 {
+				fmt.Println("The trash is empty")
+				return nil
+			}
+
+			for i, entry := range entries {
+				fmt.Printf("%3d  %s  %s\n", i+1, entry.DeletedAt.Format(trashDateFormat), entry.Name())
+			}
+			return nil
+		},
+	}
+}
+
+func newEmptyCommand() *cobra.Command {
+	var olderThan string
+
+	cmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Permanently delete notes in the trash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Emptying trash (older than %q)", olderThan))
+
+			var age time.Duration
+			if olderThan != "" {
+				parsed, err := timeutil.ParseDuration(olderThan)
+				if err != nil {
+					return fmt.Errorf("invalid %q value: %w", olderThanFlag, err)
+				}
+				age = parsed
+			}
+
+			bin := notetrash.New(root.DirManager.TrashDir())
+			removed, err := bin.Empty(age)
+			if err != nil {
+				root.AppLogger.Fail(err.Error())
+				return err
+			}
+
+			for _, entry := range removed {
+				root.AppLogger.Info(fmt.Sprintf("Permanently deleted %q", entry.Name()))
+			}
+
+			root.AppLogger.End(fmt.Sprintf("Removed %d notes from the trash", len(removed)))
+			fmt.Printf("Permanently deleted %d notes\n", len(removed))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, olderThanFlag, "", "Only delete notes trashed longer ago than this (e.g. 30d, 2w, 12h)")
+	return cmd
+}
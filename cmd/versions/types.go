@@ -0,0 +1,6 @@
+package versions
+
+type VersionsOptions struct {
+	noteName string
+	notesDir string
+}
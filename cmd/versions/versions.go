@@ -0,0 +1,78 @@
+package versions
+
+import (
+	"fmt"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	noteversions "github.com/rhysmah/note-app/internal/versions"
+	"github.com/spf13/cobra"
+)
+
+const (
+	versionsCmd      = "versions"
+	versionsCmdShort = "List saved versions of a note"
+	versionsCmdDesc  = `List the snapshots saved for a note, oldest first.
+A snapshot is saved to ~/.note-app/versions/ each time 'edit' changes a note.
+Only the newest versions.keep snapshots of each note are kept (default 20);
+set it to 0 to turn snapshots off.
+Compare versions with 'diff' and bring one back with 'restore-version'.
+Example: note-app versions meeting`
+
+	versionsTimeFormat = "2006-01-02 15:04"
+)
+
+func init() {
+	newVersionsCommand := NewVersionsCommand()
+	root.RootCmd.AddCommand(newVersionsCommand)
+}
+
+func NewVersionsCommand() *cobra.Command {
+	versionsCmdOpts := &VersionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   versionsCmd + " [note]",
+		Short: versionsCmdShort,
+		Long:  versionsCmdDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Listing versions of note %q", args[0]))
+
+			versionsCmdOpts.notesDir = root.DirManager.NotesDir()
+			versionsCmdOpts.noteName = args[0]
+
+			if err := listVersions(versionsCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to list versions of %q: %v", versionsCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Versions listed successfully")
+			return nil
+		},
+	}
+	return cmd
+}
+
+func listVersions(opts *VersionsOptions) error {
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	versions, err := noteversions.New(root.DirManager.VersionsDir()).List(note.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 {
+		fmt.Printf("No saved versions of %s\n", note.Name)
+		return nil
+	}
+
+	fmt.Printf("Versions of %s:\n", note.Name)
+	for _, version := range versions {
+		fmt.Printf("  v%-3d  %s  %s  %6d B  %s\n",
+			version.Number, version.SavedAt.Local().Format(versionsTimeFormat), version.ShortHash(), version.Size, version.Name)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package view
+
+type ViewOptions struct {
+	noteName string
+	notesDir string
+	noPager  bool
+}
@@ -0,0 +1,130 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rhysmah/note-app/cmd/root"
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	viewCmd      = "view"
+	viewCmdShort = "View the contents of a note"
+	viewCmdDesc  = `View the contents of a note in the terminal.
+The note can be given by its full file name, the name it was created with,
+a unique prefix of its ID, or its number in 'list'.
+Long notes are paged through $PAGER (default: less) when output is a terminal.
+Example: note-app view meeting`
+
+	noPagerFlag = "no-pager"
+
+	defaultPager = "less"
+	// Notes with more lines than this are sent to the pager.
+	pagerLineThreshold = 40
+	viewDateFormat     = "2006-01-02 15:04"
+)
+
+func init() {
+	newViewCommand := NewViewCommand()
+	root.RootCmd.AddCommand(newViewCommand)
+}
+
+func NewViewCommand() *cobra.Command {
+	viewCmdOpts := &ViewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   viewCmd + " [note]",
+		Short: viewCmdShort,
+		Long:  viewCmdDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root.AppLogger.Start(fmt.Sprintf("Viewing note %q", args[0]))
+
+			viewCmdOpts.notesDir = root.DirManager.NotesDir()
+			viewCmdOpts.noteName = args[0]
+
+			if err := viewNote(viewCmdOpts); err != nil {
+				root.AppLogger.Fail(fmt.Sprintf("Failed to view note %q: %v", viewCmdOpts.noteName, err))
+				return err
+			}
+
+			root.AppLogger.End("Note view completed successfully")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&viewCmdOpts.noPager, noPagerFlag, false, "Print the note directly instead of using a pager")
+	return cmd
+}
+
+func viewNote(opts *ViewOptions) error {
+	note, err := file.FindNote(root.AppLogger, opts.notesDir, opts.noteName)
+	if err != nil {
+		return err
+	}
+
+	_, content, err := file.ReadNote(note.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read note %q: %w", note.Name, err)
+	}
+
+	var out bytes.Buffer
+	writeHeader(&out, note)
+	out.Write(content)
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+
+	if shouldPage(opts, out.Bytes()) {
+		err := runPager(out.Bytes())
+		if err == nil {
+			return nil
+		}
+		root.AppLogger.Info(fmt.Sprintf("Pager unavailable, printing directly: %v", err))
+	}
+
+	_, err = os.Stdout.Write(out.Bytes())
+	return err
+}
+
+// writeHeader writes the note's title, tags and timestamps above its contents.
+func writeHeader(w io.Writer, note *file.File) {
+	fmt.Fprintln(w, note.Title)
+	fmt.Fprintf(w, "Created:  %s\n", note.DateCreated.Format(viewDateFormat))
+	fmt.Fprintf(w, "Modified: %s\n", note.DateModified.Format(viewDateFormat))
+	if len(note.Tags) > 0 {
+		fmt.Fprintf(w, "Tags:     %s\n", strings.Join(note.Tags, ", "))
+	}
+	fmt.Fprintln(w, strings.Repeat("-", max(len(note.Title), len(viewDateFormat)+10)))
+}
+
+// shouldPage reports whether the output is long enough to page and is
+// going to an interactive terminal.
+func shouldPage(opts *ViewOptions, content []byte) bool {
+	if opts.noPager || !terminal.IsTerminal(os.Stdout) {
+		return false
+	}
+	return bytes.Count(content, []byte("\n")) > pagerLineThreshold
+}
+
+// runPager pipes content through the user's $PAGER, falling back to less.
+func runPager(content []byte) error {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{defaultPager}
+	}
+
+	pagerCmd := exec.Command(pager[0], pager[1:]...)
+	pagerCmd.Stdin = bytes.NewReader(content)
+	pagerCmd.Stdout = os.Stdout
+	pagerCmd.Stderr = os.Stderr
+
+	return pagerCmd.Run()
+}
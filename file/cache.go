@@ -0,0 +1,198 @@
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/rhysmah/note-app/internal/logger"
+)
+
+// The metadata cache remembers what was read from each note's front matter,
+// keyed by the note's path and checked against its modification time and
+// size, so unchanged notes don't have to be opened on every run. It is stored
+// with encoding/gob under ~/.note-app/cache/, one file per notes directory.
+const (
+	// cacheFormatVersion is bumped whenever cacheEntry changes; older caches
+	// are discarded and rebuilt.
+	cacheFormatVersion = 3
+
+	appDirName      = ".note-app"
+	cacheDirName    = "cache"
+	cacheFileSuffix = ".gob"
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	cacheDirPermissions = 0755
+)
+
+// cacheEntry is the cached metadata of one note. A note's entry is only used
+// while the file's modification time and size are unchanged.
+type cacheEntry struct {
+	ModTime     time.Time
+	Size        int64
+	ID          string
+	Title       string
+	Tags        []string
+	DateCreated time.Time
+	WordCount   int
+}
+
+type metadataCache struct {
+	Version  int
+	NotesDir string
+	Entries  map[string]cacheEntry // keyed by path relative to NotesDir
+
+	path string
+}
+
+// CacheDir returns the directory holding the metadata cache.
+func CacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("couldn't find user's home directory: %w", err)
+	}
+	return filepath.Join(homeDir, appDirName, cacheDirName), nil
+}
+
+// ClearCache deletes the metadata cache for every notes directory. It is
+// rebuilt the next time notes are loaded.
+func ClearCache() error {
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return fmt.Errorf("failed to clear metadata cache: %w", err)
+	}
+	return nil
+}
+
+// loadMetadataCache reads the cache for notesDir. The cache only ever speeds
+// loading up, so any problem reading it yields an empty cache instead of an
+// error.
+func loadMetadataCache(logger *logger.Logger, notesDir string) *metadataCache {
+	cache := &metadataCache{
+		Version:  cacheFormatVersion,
+		NotesDir: notesDir,
+		Entries:  map[string]cacheEntry{},
+	}
+
+	cacheDir, err := CacheDir()
+	if err != nil {
+		logger.Info(fmt.Sprintf("Metadata cache disabled: %v", err))
+		return cache
+	}
+	sum := sha256.Sum256([]byte(notesDir))
+	cache.path = filepath.Join(cacheDir, hex.EncodeToString(sum[:8])+cacheFileSuffix)
+
+	cacheFile, err := os.Open(cache.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Info(fmt.Sprintf("Metadata cache is unreadable, rebuilding: %v", err))
+		}
+		return cache
+	}
+	defer cacheFile.Close()
+
+	var stored metadataCache
+	if err := gob.NewDecoder(cacheFile).Decode(&stored); err != nil {
+		logger.Info(fmt.Sprintf("Metadata cache is unreadable, rebuilding: %v", err))
+		return cache
+	}
+	if stored.Version != cacheFormatVersion || stored.NotesDir != notesDir || stored.Entries == nil {
+		logger.Info("Metadata cache is outdated, rebuilding")
+		return cache
+	}
+
+	stored.path = cache.path
+	logger.Info(fmt.Sprintf("Loaded metadata cache with %d notes", len(stored.Entries)))
+	return &stored
+}
+
+// lookup returns the cached note at relPath if the file described by info
+// hasn't changed since it was cached.
+func (c *metadataCache) lookup(notesDir, relPath string, info fs.FileInfo) (*File, bool) {
+	entry, ok := c.Entries[filepath.ToSlash(relPath)]
+	if !ok || entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+		return nil, false
+	}
+
+	f := newFileAt(relPath, notesDir)
+	f.ID = entry.ID
+	f.Title = entry.Title
+	f.Tags = entry.Tags
+	f.DateCreated = entry.DateCreated.Local()
+	f.WordCount = entry.WordCount
+	f.DateModified = info.ModTime()
+	f.Size = info.Size()
+	return f, true
+}
+
+// newCacheEntry records the metadata of f, whose file is described by info.
+func newCacheEntry(f *File, info fs.FileInfo) cacheEntry {
+	return cacheEntry{
+		ModTime:     info.ModTime(),
+		Size:        info.Size(),
+		ID:          f.ID,
+		Title:       f.Title,
+		Tags:        f.Tags,
+		DateCreated: f.DateCreated,
+		WordCount:   f.WordCount,
+	}
+}
+
+func (e cacheEntry) equal(other cacheEntry) bool {
+	return e.ModTime.Equal(other.ModTime) &&
+		e.Size == other.Size &&
+		e.ID == other.ID &&
+		e.Title == other.Title &&
+		slices.Equal(e.Tags, other.Tags) &&
+		e.DateCreated.Equal(other.DateCreated) &&
+		e.WordCount == other.WordCount
+}
+
+// save writes the cache to disk atomically. Failures are logged rather than
+// returned, since the notes themselves loaded fine.
+func (c *metadataCache) save(logger *logger.Logger) {
+	if c.path == "" {
+		return
+	}
+
+	if err := c.write(); err != nil {
+		logger.Info(fmt.Sprintf("Failed to save metadata cache: %v", err))
+		return
+	}
+	logger.Info(fmt.Sprintf("Saved metadata cache with %d notes", len(c.Entries)))
+}
+
+func (c *metadataCache) write() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), cacheDirPermissions); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), "cache-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(c); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to save cache: %w", err)
+	}
+	return nil
+}
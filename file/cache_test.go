@@ -0,0 +1,250 @@
+package file
+
+import (
+	"context"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const cachedTitle = "title from the cache"
+
+// loadTestNotes loads notesDir and returns its notes by relative path.
+func loadTestNotes(t *testing.T, notesDir string) map[string]File {
+	t.Helper()
+
+	files, warnings, err := LoadNotes(context.Background(), testLogger, notesDir, LoadOptions{})
+	if err != nil {
+		t.Fatalf("LoadNotes() error = %v", err)
+	}
+	if len(warnings) > 0 {
+		t.Fatalf("LoadNotes() warnings = %v", warnings)
+	}
+
+	byPath := make(map[string]File, len(files))
+	for _, f := range files {
+		byPath[f.RelPath()] = f
+	}
+	return byPath
+}
+
+// tamperCache changes the cached title of relPath, so a test can tell
+// whether a note was read from the cache or from disk.
+func tamperCache(t *testing.T, notesDir, relPath string) {
+	t.Helper()
+
+	cache := loadMetadataCache(testLogger, notesDir)
+	entry, ok := cache.Entries[filepath.ToSlash(relPath)]
+	if !ok {
+		t.Fatalf("%s is not cached", relPath)
+	}
+	entry.Title = cachedTitle
+	cache.Entries[filepath.ToSlash(relPath)] = entry
+	if err := cache.write(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rewriteTitle replaces the title of the note at relPath and sets its
+// modification time to modTime.
+func rewriteTitle(t *testing.T, notesDir, relPath, title string, modTime time.Time) {
+	t.Helper()
+
+	notePath := filepath.Join(notesDir, relPath)
+	fm, body, err := ReadNote(notePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fm.Title = title
+	if err := WriteNote(notePath, fm, body); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(notePath, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func modTime(t *testing.T, path string) time.Time {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.ModTime()
+}
+
+func TestCacheHit(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	relPaths := writeTestNotes(t, notesDir, 10)
+
+	first := loadTestNotes(t, notesDir)
+	if _, err := os.Stat(loadMetadataCache(testLogger, notesDir).path); err != nil {
+		t.Fatalf("cache not saved: %v", err)
+	}
+
+	tamperCache(t, notesDir, relPaths[3])
+	second := loadTestNotes(t, notesDir)
+
+	if got := second[filepath.ToSlash(relPaths[3])].Title; got != cachedTitle {
+		t.Errorf("unchanged note has title %q, want %q from the cache", got, cachedTitle)
+	}
+
+	// Everything else read from the cache matches what was read from disk.
+	for relPath, want := range first {
+		if relPath == filepath.ToSlash(relPaths[3]) {
+			continue
+		}
+		got := second[relPath]
+		if got.ID != want.ID || got.Title != want.Title || got.WordCount != want.WordCount ||
+			got.Size != want.Size || !got.DateCreated.Equal(want.DateCreated) || !got.DateModified.Equal(want.DateModified) {
+			t.Errorf("cached %s = %+v, want %+v", relPath, got, want)
+		}
+	}
+}
+
+func TestCacheInvalidatedBySizeChange(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	relPath := writeTestNotes(t, notesDir, 3)[1]
+
+	loadTestNotes(t, notesDir)
+	tamperCache(t, notesDir, relPath)
+
+	// An edit that keeps the modification time is still caught by the size.
+	rewriteTitle(t, notesDir, relPath, "A much longer title than before", modTime(t, filepath.Join(notesDir, relPath)))
+
+	if got := loadTestNotes(t, notesDir)[filepath.ToSlash(relPath)].Title; got != "A much longer title than before" {
+		t.Errorf("edited note has title %q, want the title on disk", got)
+	}
+}
+
+func TestCacheInvalidatedByModTimeChange(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	relPath := writeTestNotes(t, notesDir, 3)[1]
+
+	notes := loadTestNotes(t, notesDir)
+	original := notes[filepath.ToSlash(relPath)]
+	tamperCache(t, notesDir, relPath)
+
+	// "Note 1" becomes "Note X": the same size, but a new modification time.
+	rewriteTitle(t, notesDir, relPath, "Note X", original.DateModified.Add(time.Hour))
+
+	edited := loadTestNotes(t, notesDir)[filepath.ToSlash(relPath)]
+	if edited.Size != original.Size {
+		t.Fatalf("test edit changed the size from %d to %d", original.Size, edited.Size)
+	}
+	if edited.Title != "Note X" {
+		t.Errorf("edited note has title %q, want the title on disk", edited.Title)
+	}
+}
+
+func TestCacheDropsDeletedNotes(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	relPaths := writeTestNotes(t, notesDir, 5)
+
+	loadTestNotes(t, notesDir)
+	if err := os.Remove(filepath.Join(notesDir, relPaths[2])); err != nil {
+		t.Fatal(err)
+	}
+
+	if notes := loadTestNotes(t, notesDir); len(notes) != 4 {
+		t.Errorf("loaded %d notes after a deletion, want 4", len(notes))
+	}
+
+	cache := loadMetadataCache(testLogger, notesDir)
+	if _, ok := cache.Entries[filepath.ToSlash(relPaths[2])]; ok {
+		t.Errorf("deleted note %s is still cached", relPaths[2])
+	}
+	if len(cache.Entries) != 4 {
+		t.Errorf("cache has %d entries, want 4", len(cache.Entries))
+	}
+}
+
+func TestCacheRecoversFromCorruptFile(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	writeTestNotes(t, notesDir, 5)
+
+	want := loadTestNotes(t, notesDir)
+	cachePath := loadMetadataCache(testLogger, notesDir).path
+	if err := os.WriteFile(cachePath, []byte("not a gob stream"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := loadTestNotes(t, notesDir)
+	if len(got) != len(want) {
+		t.Fatalf("loaded %d notes with a corrupt cache, want %d", len(got), len(want))
+	}
+	for relPath, f := range want {
+		if got[relPath].Title != f.Title {
+			t.Errorf("%s has title %q, want %q", relPath, got[relPath].Title, f.Title)
+		}
+	}
+
+	// The corrupt file was replaced by a working cache.
+	if entries := len(loadMetadataCache(testLogger, notesDir).Entries); entries != len(want) {
+		t.Errorf("rebuilt cache has %d entries, want %d", entries, len(want))
+	}
+}
+
+func TestCacheIgnoresOldVersion(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	relPath := writeTestNotes(t, notesDir, 3)[0]
+
+	loadTestNotes(t, notesDir)
+	tamperCache(t, notesDir, relPath)
+
+	// Rewrite the tampered cache as if an older release had saved it.
+	cache := loadMetadataCache(testLogger, notesDir)
+	cache.Version = cacheFormatVersion - 1
+	cacheFile, err := os.Create(cache.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewEncoder(cacheFile).Encode(cache); err != nil {
+		t.Fatal(err)
+	}
+	cacheFile.Close()
+
+	if got := loadTestNotes(t, notesDir)[filepath.ToSlash(relPath)].Title; got == cachedTitle {
+		t.Errorf("note read from a cache with version %d", cacheFormatVersion-1)
+	}
+	if version := loadMetadataCache(testLogger, notesDir).Version; version != cacheFormatVersion {
+		t.Errorf("rebuilt cache has version %d, want %d", version, cacheFormatVersion)
+	}
+}
+
+func TestClearCache(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	relPath := writeTestNotes(t, notesDir, 3)[0]
+
+	loadTestNotes(t, notesDir)
+	tamperCache(t, notesDir, relPath)
+
+	if err := ClearCache(); err != nil {
+		t.Fatalf("ClearCache() error = %v", err)
+	}
+	cacheDir, err := CacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Errorf("cache directory still exists after ClearCache(): %v", err)
+	}
+
+	if got := loadTestNotes(t, notesDir)[filepath.ToSlash(relPath)].Title; got == cachedTitle {
+		t.Error("note read from a cleared cache")
+	}
+
+	// Clearing an already empty cache is fine.
+	if err := ClearCache(); err != nil {
+		t.Errorf("ClearCache() on a missing cache error = %v", err)
+	}
+}
@@ -1,12 +1,19 @@
 package file
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rhysmah/note-app/internal/logger"
@@ -14,67 +21,126 @@ import (
 
 const dateTimeRegexPattern = `(\d{4})_(\d{2})_(\d{2})_(\d{2})_(\d{2})`
 
+// Octal: 4 = read, 2 = write, 1 = execute
+const notePermissions = 0644
+
 var dateTimeRegex = regexp.MustCompile(dateTimeRegexPattern)
 
+// File describes a note on disk. ID, Title and Tags come from the note's
+// front matter. For notes written before front matter existed, DateCreated
+// comes from the timestamp in the file name and ID is derived from the
+// note's path until a command writes the note and gives it a random ID.
+// Name is the note's file name and Folder the slash-separated folder holding
+// it, relative to the notes directory ("" for notes at the top level).
+// Size is the note's size in bytes, front matter included; WordCount only
+// counts the words of the body.
+// Index is the note's 1-based position when all notes are ordered by path,
+// and is only set for files returned by PrepareNoteFiles.
 type File struct {
 	Name         string
+	Folder       string
 	FilePath     string
+	Index        int
+	ID           string
+	Title        string
+	Tags         []string
 	DateCreated  time.Time
 	DateModified time.Time
+	Size         int64
+	WordCount    int
 }
 
-func NewFile(fileName, notesDir string, logger *logger.Logger) (*File, error) {
+// NewFile reads the note at relPath, a path relative to notesDir.
+func NewFile(relPath, notesDir string, logger *logger.Logger) (*File, error) {
 
 	// Validation
 	if logger == nil {
 		return nil, fmt.Errorf("logger cannot be nil")
 	}
-	if fileName == "" || notesDir == "" {
-		return nil, fmt.Errorf("fileName and notesDir cannot be empty")
+	if relPath == "" || notesDir == "" {
+		return nil, fmt.Errorf("relPath and notesDir cannot be empty")
 	}
 
 	// File Creation
-	newFile := &File{
-		Name:     fileName,
-		FilePath: filepath.Join(notesDir, fileName),
-	}
+	newFile := newFileAt(relPath, notesDir)
+	fileName := newFile.Name
 
-	dateCreated, err := getDateCreated(newFile.FilePath, logger)
+	fm, hasFrontMatter, wordCount, err := readNoteMetadata(newFile.FilePath)
 	if err != nil {
-		return nil, fmt.Errorf("error accessing file's Date Created: %w", err)
+		logger.Fail(fmt.Sprintf("Failed to read front matter from %q: %v", fileName, err))
+		return nil, fmt.Errorf("error reading file's front matter: %w", err)
+	}
+
+	newFile.ID = fm.ID
+	if newFile.ID == "" {
+		newFile.ID = legacyID(notesDir, relPath)
+	}
+	newFile.Title = fm.Title
+	newFile.Tags = fm.Tags
+	newFile.WordCount = wordCount
+	if newFile.Title == "" {
+		newFile.Title = DisplayName(fileName)
+	}
+
+	if hasFrontMatter && !fm.Created.IsZero() {
+		newFile.DateCreated = fm.Created.Local()
+	} else {
+		dateCreated, err := getDateCreated(newFile.FilePath, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error accessing file's Date Created: %w", err)
+		}
+		newFile.DateCreated = dateCreated
 	}
-	newFile.DateCreated = dateCreated
 
-	dateModified, err := getDateModified(newFile.FilePath, logger)
+	fileInfo, err := getFileInfo(newFile.FilePath, logger)
 	if err != nil {
-		return nil, fmt.Errorf("error accessing file's Date Modified: %w", err)
+		return nil, fmt.Errorf("error accessing file info: %w", err)
 	}
-	newFile.DateModified = dateModified
+	newFile.DateModified = fileInfo.ModTime()
+	newFile.Size = fileInfo.Size()
 
 	return newFile, nil
 }
 
-func getDateModified(filePath string, logger *logger.Logger) (time.Time, error) {
-	logger.Start("Getting date modified from file...")
+// newFileAt returns a File locating the note at relPath, a path relative to
+// notesDir, without reading it.
+func newFileAt(relPath, notesDir string) *File {
+	folder, fileName := path.Split(filepath.ToSlash(relPath))
+	return &File{
+		Name:     fileName,
+		Folder:   strings.TrimSuffix(folder, "/"),
+		FilePath: filepath.Join(notesDir, relPath),
+	}
+}
+
+// RelPath returns the note's slash-separated path relative to the notes
+// directory.
+func (f File) RelPath() string {
+	return path.Join(f.Folder, f.Name)
+}
+
+func getFileInfo(filePath string, logger *logger.Logger) (fs.FileInfo, error) {
+	logger.Start("Getting date modified and size from file...")
 
 	fileInfo, err := os.Stat(filePath)
 
 	if err != nil {
 		errMsg := fmt.Sprintf("error accessing file info: %v", err)
 		logger.Fail(errMsg)
-		return time.Time{}, errors.New(errMsg)
+		return nil, errors.New(errMsg)
 	}
 
-	return fileInfo.ModTime(), nil
+	return fileInfo, nil
 }
 
 func getDateCreated(filePath string, logger *logger.Logger) (time.Time, error) {
 
 	logger.Start(fmt.Sprintf("Extracting creation date from file %q", filePath))
 
-	matches := dateTimeRegex.FindStringSubmatch(filePath)
+	// Only the file name carries the timestamp; folder names may contain digits too.
+	matches := dateTimeRegex.FindStringSubmatch(filepath.Base(filePath))
 	if len(matches) != 6 { // Original + 5 capture groups
-		return time.Time{}, fmt.Errorf("invalid filename format: %q", filePath)
+		return time.Time{}, fmt.Errorf("invalid filename format: %q has no _YYYY_MM_DD_HH_MM timestamp", filepath.Base(filePath))
 	}
 
 	return validateNoteCreatedTime(matches, logger)
@@ -124,60 +190,266 @@ func validateNoteCreatedTime(dateTimeCmp []string, logger *logger.Logger) (time.
 // They are specific to this command's implementation and shouldn't be used elsewhere.
 // ----------------------
 
-// prepareNoteFiles reads and processes notes from the specified directory.
-// It returns a slice of File objects or an error if the operation fails.
+// LoadOptions controls how LoadNotes reads notes.
+type LoadOptions struct {
+	// Strict stops at the first file that can't be read as a note instead
+	// of skipping it.
+	Strict bool
+	// Workers is how many notes are read at once. Zero uses one worker per CPU.
+	Workers int
+}
+
+// LoadWarning describes a file that was skipped because it couldn't be read
+// as a note.
+type LoadWarning struct {
+	Path string // relative to the notes directory
+	Err  error
+}
+
+func (w LoadWarning) Error() string {
+	return fmt.Sprintf("%s: %v", w.Path, w.Err)
+}
+
+// PrepareNoteFiles reads and processes notes from the specified directory.
+// Files that can't be read as notes are skipped and logged; use LoadNotes
+// to see them. It returns an error if the directory itself can't be read.
 func PrepareNoteFiles(logger *logger.Logger, notesDir string) ([]File, error) {
+	files, _, err := LoadNotes(context.Background(), logger, notesDir, LoadOptions{})
+	return files, err
+}
+
+// LoadNotes reads and processes notes from the specified directory,
+// returning a warning for every file skipped because it couldn't be read as
+// a note. With opts.Strict set, the first such file is an error instead.
+// Notes are read concurrently but returned in path order. Loading stops
+// early if ctx is cancelled.
+func LoadNotes(ctx context.Context, logger *logger.Logger, notesDir string, opts LoadOptions) ([]File, []LoadWarning, error) {
 	logger.Start(fmt.Sprintf("Preparing notes in directory %q...", notesDir))
 
 	notes, err := ReadNotesDirectory(logger, notesDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read notes directory %q: %w", notesDir, err)
+		return nil, nil, fmt.Errorf("failed to read notes directory %q: %w", notesDir, err)
 	}
 
-	files, err := buildFileObjects(logger, notesDir, notes)
+	files, warnings, err := buildFileObjects(ctx, logger, notesDir, notes, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build File objects for notes in directory %q: %w", notesDir, err)
+		return nil, nil, fmt.Errorf("failed to build File objects for notes in directory %q: %w", notesDir, err)
 	}
 
-	return files, nil
+	return files, warnings, nil
 }
 
-// buildFileObjects creates File objects from directory entries.
-// It processes each note file and returns a slice of File objects.
-func buildFileObjects(logger *logger.Logger, notesDir string, notes []os.DirEntry) ([]File, error) {
-	files := make([]File, 0, len(notes))
+// loadResult is the outcome of loading the note at one position in the list.
+// entry is the note's metadata as it should now be cached.
+type loadResult struct {
+	file  *File
+	entry cacheEntry
+	err   error
+}
 
-	for _, note := range notes {
-		logger.Info(fmt.Sprintf("Processing note: %s", note.Name()))
+// buildFileObjects creates File objects from note paths relative to notesDir
+// using a bounded pool of workers. Results keep the order of notes. Files
+// that fail to load are collected as warnings unless opts.Strict is set, in
+// which case the first failure in path order is returned. Notes unchanged
+// since they were last cached aren't read at all.
+func buildFileObjects(ctx context.Context, logger *logger.Logger, notesDir string, notes []string, opts LoadOptions) ([]File, []LoadWarning, error) {
+	// loadCtx is also cancelled by a strict failure; ctx only by the caller.
+	loadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(notes))
+
+	cache := loadMetadataCache(logger, notesDir)
+	results := make([]loadResult, len(notes))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A job that was taken is always finished, even after a
+			// cancellation: jobs are handed out in order, so every note before
+			// a strict failure has then been loaded and the failure is the
+			// first in path order. Cancellation only stops the dispatching.
+			for i := range jobs {
+				logger.Info(fmt.Sprintf("Processing note: %s", notes[i]))
+				results[i].file, results[i].entry, results[i].err = loadCachedNote(logger, cache, notesDir, notes[i])
+
+				if results[i].err != nil && opts.Strict {
+					cancel()
+				}
+			}
+		}()
+	}
 
-		newFile, err := NewFile(note.Name(), notesDir, logger)
-		if err != nil {
-			logger.Fail(fmt.Sprintf("Failed to create File object for %q: %v", note.Name(), err))
-			return nil, fmt.Errorf("failed to create File object for %q: %w", note.Name(), err)
+dispatch:
+	for i := range notes {
+		select {
+		case jobs <- i:
+		case <-loadCtx.Done():
+			break dispatch
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		files = append(files, *newFile)
+	if err := ctx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("loading notes was cancelled: %w", err)
 	}
 
+	// After a strict failure, later notes may not have been dispatched, but
+	// the loop below returns at that failure before reaching them.
+	files := make([]File, 0, len(notes))
+	var warnings []LoadWarning
+	entries := make(map[string]cacheEntry, len(notes))
+
+	for i, result := range results {
+		if result.err != nil {
+			if opts.Strict {
+				return nil, nil, result.err
+			}
+
+			logger.Info(fmt.Sprintf("Skipping %q: %v", notes[i], result.err))
+			// The path is already in the warning, so keep only the cause.
+			warnings = append(warnings, LoadWarning{Path: filepath.ToSlash(notes[i]), Err: errors.Unwrap(result.err)})
+			continue
+		}
+
+		// ReadNotesDirectory returns notes sorted by path.
+		result.file.Index = len(files) + 1
+		files = append(files, *result.file)
+		entries[result.file.RelPath()] = result.entry
+	}
+
+	// Entries for deleted notes and files that no longer load are dropped.
+	if !maps.EqualFunc(cache.Entries, entries, cacheEntry.equal) {
+		cache.Entries = entries
+		cache.save(logger)
+	}
+
+	if len(warnings) > 0 {
+		logger.Info(fmt.Sprintf("Skipped %d files that couldn't be read as notes", len(warnings)))
+	}
 	logger.Success(fmt.Sprintf("Successfully processed %d notes", len(files)))
-	return files, nil
+	return files, warnings, nil
 }
 
-// readNotesDirectory reads the contents of the notes directory.
-// It returns an error if the directory is empty or cannot be read.
-func ReadNotesDirectory(logger *logger.Logger, notesDir string) ([]os.DirEntry, error) {
-	notes, err := os.ReadDir(notesDir)
+// loadNote creates the File for a single note.
+func loadNote(logger *logger.Logger, notesDir, note string) (*File, error) {
+	newFile, err := NewFile(note, notesDir, logger)
+	if err != nil {
+		logger.Fail(fmt.Sprintf("Failed to create File object for %q: %v", note, err))
+		return nil, fmt.Errorf("failed to create File object for %q: %w", note, err)
+	}
+	return newFile, nil
+}
+
+// loadCachedNote returns the note at relPath from the cache if the file is
+// unchanged since it was cached, and otherwise reads it with loadNote. The
+// returned entry describes the note as it is now on disk.
+func loadCachedNote(logger *logger.Logger, cache *metadataCache, notesDir, note string) (*File, cacheEntry, error) {
+	info, err := os.Stat(filepath.Join(notesDir, note))
+	if err != nil {
+		return nil, cacheEntry{}, fmt.Errorf("failed to access %q: %w", note, err)
+	}
+
+	if cached, ok := cache.lookup(notesDir, note, info); ok {
+		return cached, newCacheEntry(cached, info), nil
+	}
+
+	newFile, err := loadNote(logger, notesDir, note)
+	if err != nil {
+		return nil, cacheEntry{}, err
+	}
+	return newFile, newCacheEntry(newFile, info), nil
+}
+
+// ReadNotesDirectory finds every note in the notes directory and its
+// folders, returning their paths relative to notesDir sorted by path.
+// Hidden files and folders, such as the .git directory used for history,
+// are skipped, as is anything listed in the ignore file. It returns an
+// error if the directory is empty or cannot be read.
+func ReadNotesDirectory(logger *logger.Logger, notesDir string) ([]string, error) {
+	entries, err := os.ReadDir(notesDir)
 	if err != nil {
 		logger.Fail(fmt.Sprintf("Failed to read notes directory %q: %v", notesDir, err))
 		return nil, fmt.Errorf("failed to read notes directory %q: %w", notesDir, err)
 	}
 
-	if len(notes) == 0 {
+	if len(entries) == 0 {
 		logger.Info(fmt.Sprintf("No notes found in directory %q", notesDir))
 		return nil, fmt.Errorf("no notes found in directory %q", notesDir)
 	}
 
+	var notes []string
+	err = walkNotesDirectory(notesDir, func(relPath string, entry fs.DirEntry) {
+		if entry.Type().IsRegular() {
+			notes = append(notes, relPath)
+		}
+	})
+	if err != nil {
+		logger.Fail(fmt.Sprintf("Failed to read notes directory %q: %v", notesDir, err))
+		return nil, err
+	}
+
 	logger.Info(fmt.Sprintf("Found %d notes in %q directory", len(notes), notesDir))
 
 	return notes, nil
 }
+
+// ReadFolders returns every folder inside the notes directory as a
+// slash-separated path relative to notesDir, sorted by path.
+func ReadFolders(notesDir string) ([]string, error) {
+	var folders []string
+	err := walkNotesDirectory(notesDir, func(relPath string, entry fs.DirEntry) {
+		if entry.IsDir() {
+			folders = append(folders, filepath.ToSlash(relPath))
+		}
+	})
+	return folders, err
+}
+
+// walkNotesDirectory calls visit, in lexical order, for every entry below
+// notesDir that isn't hidden or excluded by the ignore file.
+func walkNotesDirectory(notesDir string, visit func(relPath string, entry fs.DirEntry)) error {
+	ignore, err := loadIgnoreRules(notesDir)
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(notesDir, func(entryPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", entryPath, err)
+		}
+		if entryPath == notesDir {
+			return nil
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(notesDir, entryPath)
+		if err != nil {
+			return fmt.Errorf("failed to locate %q in notes directory: %w", entryPath, err)
+		}
+
+		if ignore.matches(filepath.ToSlash(relPath), entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		visit(relPath, entry)
+		return nil
+	})
+}
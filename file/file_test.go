@@ -0,0 +1,162 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rhysmah/note-app/internal/logger"
+)
+
+// testLogger discards everything logged; an unopened Logger writes nothing.
+var testLogger = &logger.Logger{}
+
+// writeTestNotes creates count notes in dir, spread over a few folders, and
+// returns their paths relative to dir.
+func writeTestNotes(tb testing.TB, dir string, count int) []string {
+	tb.Helper()
+
+	created := time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)
+	folders := []string{"", "work", "work/meetings", "personal"}
+
+	relPaths := make([]string, 0, count)
+	for i := range count {
+		folder := folders[i%len(folders)]
+		name := NoteFileName(fmt.Sprintf("note-%05d", i), created.Add(time.Duration(i)*time.Minute))
+		relPath := filepath.Join(folder, name)
+
+		if err := os.MkdirAll(filepath.Join(dir, folder), 0755); err != nil {
+			tb.Fatal(err)
+		}
+		fm := FrontMatter{
+			ID:      fmt.Sprintf("%012x", i),
+			Title:   fmt.Sprintf("Note %d", i),
+			Created: created,
+			Tags:    []string{"tag" + fmt.Sprint(i%7)},
+		}
+		body := []byte(strings.Repeat("some words in the body\n", i%20+1))
+		if err := WriteNote(filepath.Join(dir, relPath), fm, body); err != nil {
+			tb.Fatal(err)
+		}
+		relPaths = append(relPaths, relPath)
+	}
+	return relPaths
+}
+
+// useTempHome points the metadata cache at a temporary home directory.
+func useTempHome(tb testing.TB) {
+	tb.Helper()
+	tb.Setenv("HOME", tb.TempDir())
+}
+
+// clearCache removes the metadata cache, so notes are read from disk again.
+func clearCache(tb testing.TB) {
+	tb.Helper()
+	dir, err := CacheDir()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestLoadNotesOrderIndependentOfWorkers(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	writeTestNotes(t, notesDir, 500)
+
+	load := func(workers int) []File {
+		t.Helper()
+		// Clear the cache so every note is read by the workers.
+		clearCache(t)
+		files, warnings, err := LoadNotes(context.Background(), testLogger, notesDir, LoadOptions{Workers: workers})
+		if err != nil {
+			t.Fatalf("LoadNotes(Workers: %d) error = %v", workers, err)
+		}
+		if len(warnings) > 0 {
+			t.Fatalf("LoadNotes(Workers: %d) warnings = %v", workers, warnings)
+		}
+		return files
+	}
+
+	sequential := load(1)
+	if len(sequential) != 500 {
+		t.Fatalf("loaded %d notes, want 500", len(sequential))
+	}
+	for i := 1; i < len(sequential); i++ {
+		if sequential[i-1].RelPath() >= sequential[i].RelPath() {
+			t.Fatalf("notes not in path order: %q before %q", sequential[i-1].RelPath(), sequential[i].RelPath())
+		}
+	}
+
+	for _, workers := range []int{2, 8, 64} {
+		concurrent := load(workers)
+		if !reflect.DeepEqual(sequential, concurrent) {
+			t.Errorf("Workers: %d loaded notes differently from Workers: 1", workers)
+		}
+	}
+}
+
+func TestLoadNotesStrictReturnsFirstFailure(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+	writeTestNotes(t, notesDir, 200)
+
+	// Without a timestamp or front matter, a note has no creation date.
+	for _, name := range []string{"note-00100-broken.txt", "z-broken.txt"} {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte("no date\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for range 20 {
+		_, _, err := LoadNotes(context.Background(), testLogger, notesDir, LoadOptions{Strict: true, Workers: 16})
+		if err == nil {
+			t.Fatal("LoadNotes() in strict mode returned no error")
+		}
+		if errors.Is(err, context.Canceled) {
+			t.Fatalf("LoadNotes() in strict mode error = %v, want the failing note's error", err)
+		}
+		if !strings.Contains(err.Error(), "note-00100-broken.txt") {
+			t.Fatalf("LoadNotes() in strict mode error = %v, want the first failure, note-00100-broken.txt", err)
+		}
+	}
+}
+
+func BenchmarkLoadNotes(b *testing.B) {
+	useTempHome(b)
+	notesDir := b.TempDir()
+	writeTestNotes(b, notesDir, 10000)
+
+	b.Run("uncached", func(b *testing.B) {
+		for range b.N {
+			b.StopTimer()
+			clearCache(b)
+			b.StartTimer()
+
+			if _, _, err := LoadNotes(context.Background(), testLogger, notesDir, LoadOptions{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		if _, _, err := LoadNotes(context.Background(), testLogger, notesDir, LoadOptions{}); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+
+		for range b.N {
+			if _, _, err := LoadNotes(context.Background(), testLogger, notesDir, LoadOptions{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Front matter is a block of 'key: value' lines at the top of a note,
+// delimited by '---' lines:
+//
+//	---
+//	id: 3f9a1c2b7d4e
+//	title: "Weekly sync"
+//	created: 2026-01-02T15:04:05Z
+//	tags: [work, meeting]
+//	---
+const (
+	frontMatterDelimiter = "---"
+	// Guards against scanning a whole note that happens to open with '---'.
+	frontMatterMaxLines = 100
+	idByteLength        = 6
+)
+
+const (
+	keyID      = "id"
+	keyTitle   = "title"
+	keyCreated = "created"
+	keyTags    = "tags"
+)
+
+// FrontMatter is the metadata stored at the top of a note.
+type FrontMatter struct {
+	ID      string
+	Title   string
+	Created time.Time
+	Tags    []string
+
+	// extra holds lines with keys this package doesn't know about so they
+	// survive a rewrite of the note.
+	extra []string
+}
+
+// NewID returns a random identifier for a new note.
+func NewID() (string, error) {
+	b := make([]byte, idByteLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate note ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Marshal renders the front matter block, including both delimiters.
+func (fm FrontMatter) Marshal() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(frontMatterDelimiter + "\n")
+	if fm.ID != "" {
+		fmt.Fprintf(&buf, "%s: %s\n", keyID, fm.ID)
+	}
+	if fm.Title != "" {
+		fmt.Fprintf(&buf, "%s: %s\n", keyTitle, strconv.Quote(fm.Title))
+	}
+	if !fm.Created.IsZero() {
+		fmt.Fprintf(&buf, "%s: %s\n", keyCreated, fm.Created.Format(time.RFC3339))
+	}
+	if len(fm.Tags) > 0 {
+		fmt.Fprintf(&buf, "%s: [%s]\n", keyTags, strings.Join(fm.Tags, ", "))
+	}
+	for _, line := range fm.extra {
+		buf.WriteString(line + "\n")
+	}
+	buf.WriteString(frontMatterDelimiter + "\n")
+
+	return buf.Bytes()
+}
+
+// ParseFrontMatter splits note content into its front matter and body.
+// If the content has no front matter block, found is false and body is the
+// unchanged content. A block that doesn't parse as front matter, such as a
+// body that opens with a '---' rule, counts as no front matter.
+func ParseFrontMatter(content []byte) (fm FrontMatter, body []byte, found bool) {
+	reader := bufio.NewReader(bytes.NewReader(content))
+
+	lines, consumed, found := scanFrontMatter(reader)
+	if !found {
+		return FrontMatter{}, content, false
+	}
+
+	fm, err := parseFrontMatterLines(lines)
+	if err != nil {
+		return FrontMatter{}, content, false
+	}
+
+	return fm, content[consumed:], true
+}
+
+// ReadNote reads the note at filePath and splits it into front matter and body.
+func ReadNote(filePath string) (FrontMatter, []byte, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return FrontMatter{}, nil, fmt.Errorf("failed to read note: %w", err)
+	}
+
+	fm, body, _ := ParseFrontMatter(content)
+	return fm, body, nil
+}
+
+// WriteNote writes front matter followed by body to filePath, replacing
+// any existing content.
+func WriteNote(filePath string, fm FrontMatter, body []byte) error {
+	content := append(fm.Marshal(), body...)
+
+	if err := os.WriteFile(filePath, content, notePermissions); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	return nil
+}
+
+// readNoteMetadata parses a note's front matter and counts the words in its
+// body, reading the note once.
+func readNoteMetadata(filePath string) (fm FrontMatter, found bool, words int, err error) {
+	noteFile, err := os.Open(filePath)
+	if err != nil {
+		return FrontMatter{}, false, 0, err
+	}
+	defer noteFile.Close()
+
+	reader := bufio.NewReader(noteFile)
+	lines, _, found := scanFrontMatter(reader)
+	if found {
+		// A block that doesn't parse, such as a body opening with a '---'
+		// rule, is treated as part of the body, as ParseFrontMatter does.
+		if fm, err = parseFrontMatterLines(lines); err != nil {
+			fm, found = FrontMatter{}, false
+		}
+	}
+	if !found {
+		// Without front matter, the lines already scanned belong to the body.
+		if _, err := noteFile.Seek(0, io.SeekStart); err != nil {
+			return FrontMatter{}, false, 0, err
+		}
+		reader.Reset(noteFile)
+	}
+
+	words, err = countWords(reader)
+	if err != nil {
+		return FrontMatter{}, false, 0, err
+	}
+	return fm, found, words, nil
+}
+
+// countWords counts the runs of non-space characters read from reader.
+func countWords(reader *bufio.Reader) (int, error) {
+	words := 0
+	inWord := false
+
+	for {
+		char, _, err := reader.ReadRune()
+		if err == io.EOF {
+			return words, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if unicode.IsSpace(char) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			words++
+		}
+	}
+}
+
+// scanFrontMatter returns the lines between the opening and closing
+// delimiters and the number of bytes consumed, including the closing line.
+func scanFrontMatter(reader *bufio.Reader) (lines []string, consumed int, found bool) {
+	for i := 0; i <= frontMatterMaxLines; i++ {
+		line, err := reader.ReadString('\n')
+		consumed += len(line)
+		trimmed := strings.TrimRight(line, "\r\n")
+
+		if i == 0 {
+			if trimmed != frontMatterDelimiter {
+				return nil, 0, false
+			}
+		} else if trimmed == frontMatterDelimiter {
+			return lines, consumed, true
+		} else {
+			lines = append(lines, trimmed)
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, false
+		}
+	}
+	return nil, 0, false
+}
+
+func parseFrontMatterLines(lines []string) (FrontMatter, error) {
+	var fm FrontMatter
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return FrontMatter{}, fmt.Errorf("expected 'key: value', got %q", line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case keyID:
+			fm.ID = value
+
+		case keyTitle:
+			title, err := unquote(value)
+			if err != nil {
+				return FrontMatter{}, fmt.Errorf("invalid title %q: %w", value, err)
+			}
+			fm.Title = title
+
+		case keyCreated:
+			created, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return FrontMatter{}, fmt.Errorf("invalid created time %q: %w", value, err)
+			}
+			fm.Created = created
+
+		case keyTags:
+			fm.Tags = parseTagList(value)
+
+		default:
+			fm.extra = append(fm.extra, line)
+		}
+	}
+
+	return fm, nil
+}
+
+// parseTagList parses '[a, b]' or 'a, b' into a slice of tags. Tags are
+// folded the way NormalizeTag folds tags given on the command line, so a
+// hand-written 'Work' matches --tag work; duplicates are dropped.
+func parseTagList(value string) []string {
+	value = strings.TrimPrefix(strings.TrimSuffix(value, "]"), "[")
+
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		tag, err := unquote(strings.TrimSpace(tag))
+		if err != nil {
+			continue
+		}
+		tag = foldTag(tag)
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+func unquote(value string) (string, error) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if value[0] == '\'' {
+			return strings.Trim(value, "'"), nil
+		}
+		return strconv.Unquote(value)
+	}
+	return value, nil
+}
+
+// legacyID derives an ID for a note written before IDs existed from its
+// location, relPath within notesDir, so it can be referred to before a
+// command writes it. Moving such a note outside the app changes the ID;
+// AssignID gives it a permanent one.
+func legacyID(notesDir, relPath string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(notesDir) + "\x00" + filepath.ToSlash(relPath)))
+	return hex.EncodeToString(sum[:idByteLength])
+}
+
+// AssignID writes a new random ID into the front matter of a note that has
+// none, such as one written before IDs existed, and updates f.ID. Commands
+// call it before changing a note in a way that would otherwise change its
+// derived ID. The note's modification time is kept.
+func AssignID(f *File) error {
+	fm, body, err := ReadNote(f.FilePath)
+	if err != nil {
+		return err
+	}
+	if fm.ID != "" {
+		f.ID = fm.ID
+		return nil
+	}
+
+	info, err := os.Stat(f.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to access note: %w", err)
+	}
+
+	fm, body, err = ReadNoteForUpdate(f)
+	if err != nil {
+		return err
+	}
+	if err := WriteNote(f.FilePath, fm, body); err != nil {
+		return err
+	}
+	if err := os.Chtimes(f.FilePath, time.Now(), info.ModTime()); err != nil {
+		return fmt.Errorf("failed to restore modification time: %w", err)
+	}
+
+	f.ID = fm.ID
+	return nil
+}
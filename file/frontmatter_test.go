@@ -0,0 +1,85 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseFrontMatterFoldsTags(t *testing.T) {
+	content := []byte("---\ntags: [Work, \"#Meeting\", work, ' Q3-Plan ']\n---\nbody\n")
+
+	fm, _, found := ParseFrontMatter(content)
+	if !found {
+		t.Fatal("front matter not found")
+	}
+
+	want := []string{"work", "meeting", "q3-plan"}
+	if !slices.Equal(fm.Tags, want) {
+		t.Errorf("tags = %q, want %q", fm.Tags, want)
+	}
+
+	f := File{Tags: fm.Tags}
+	if !f.HasTags("work", "meeting") {
+		t.Errorf("HasTags(work, meeting) = false for tags %q", f.Tags)
+	}
+	if removed := fm.RemoveTags("work"); !slices.Equal(removed, []string{"work"}) {
+		t.Errorf("RemoveTags(work) removed %q, want [work]", removed)
+	}
+}
+
+func TestLegacyIDs(t *testing.T) {
+	useTempHome(t)
+	notesDir := t.TempDir()
+
+	// Two notes without front matter share a file name in different folders.
+	name := NoteFileName("kickoff", time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC))
+	for _, folder := range []string{"alpha", "beta"} {
+		if err := os.MkdirAll(filepath.Join(notesDir, folder), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(notesDir, folder, name), []byte("legacy note\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := loadTestNotes(t, notesDir)
+	alpha, beta := first["alpha/"+name], first["beta/"+name]
+	if alpha.ID == "" || alpha.ID == beta.ID {
+		t.Fatalf("legacy notes in different folders got IDs %q and %q", alpha.ID, beta.ID)
+	}
+
+	// Loading doesn't write the derived ID, but derives the same one again.
+	content, err := os.ReadFile(alpha.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "legacy note\n" {
+		t.Errorf("loading rewrote a legacy note:\n%s", content)
+	}
+	clearCache(t)
+	if again := loadTestNotes(t, notesDir)["alpha/"+name].ID; again != alpha.ID {
+		t.Errorf("legacy ID changed between loads from %q to %q", alpha.ID, again)
+	}
+
+	// AssignID replaces the derived ID with a permanent random one.
+	derived := alpha.ID
+	if err := AssignID(&alpha); err != nil {
+		t.Fatalf("AssignID() error = %v", err)
+	}
+	if alpha.ID == derived || len(alpha.ID) != 2*idByteLength {
+		t.Errorf("AssignID() set ID %q, want a new random ID", alpha.ID)
+	}
+	fm, body, err := ReadNote(alpha.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fm.ID != alpha.ID || string(body) != "legacy note\n" {
+		t.Errorf("after AssignID() the note has ID %q and body %q", fm.ID, body)
+	}
+	if info, err := os.Stat(alpha.FilePath); err != nil || !info.ModTime().Equal(alpha.DateModified) {
+		t.Errorf("AssignID() changed the modification time")
+	}
+}
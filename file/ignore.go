@@ -0,0 +1,95 @@
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the file at the top of the notes directory listing files
+// and folders that aren't notes.
+//
+// Each line is a glob pattern as accepted by path.Match. Blank lines and lines
+// starting with '#' are ignored. A pattern without a '/' matches a file or
+// folder name anywhere in the tree; a pattern containing a '/' is matched
+// against the path relative to the notes directory. A trailing '/' only
+// matches folders, and everything inside an ignored folder is ignored too.
+const IgnoreFileName = ".noteignore"
+
+type ignorePattern struct {
+	glob     string
+	anchored bool // match against the whole relative path rather than the name
+	dirOnly  bool
+}
+
+type ignoreRules []ignorePattern
+
+// loadIgnoreRules reads the ignore file in notesDir. A missing file yields
+// no rules.
+func loadIgnoreRules(notesDir string) (ignoreRules, error) {
+	ignoreFile, err := os.Open(filepath.Join(notesDir, IgnoreFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", IgnoreFileName, err)
+	}
+	defer ignoreFile.Close()
+
+	var rules ignoreRules
+	scanner := bufio.NewScanner(ignoreFile)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern := ignorePattern{}
+		if strings.HasSuffix(line, "/") {
+			pattern.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			pattern.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		pattern.glob = line
+
+		// Surface typos such as an unclosed '[' rather than silently matching nothing.
+		if _, err := path.Match(pattern.glob, ""); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern %q: %w", IgnoreFileName, lineNum, scanner.Text(), err)
+		}
+
+		rules = append(rules, pattern)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFileName, err)
+	}
+	return rules, nil
+}
+
+// matches reports whether the entry at relPath, a slash-separated path
+// relative to the notes directory, should be ignored.
+func (rules ignoreRules) matches(relPath string, isDir bool) bool {
+	for _, pattern := range rules {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+
+		target := path.Base(relPath)
+		if pattern.anchored {
+			target = relPath
+		}
+
+		if matched, _ := path.Match(pattern.glob, target); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// IllegalNameChars may not appear in note names given by the user.
+	IllegalNameChars = "\\/:*?\"<>|: ."
+	// FileNameTimeFormat is the timestamp appended to every note's file name.
+	FileNameTimeFormat = "2006_01_02_15_04"
+
+	noteFileExtension = ".txt"
+)
+
+// NoteFileName returns the on-disk file name for a note: the user's name
+// followed by the creation timestamp, e.g. 'meeting_2026_01_02_15_04.txt'.
+func NoteFileName(name string, created time.Time) string {
+	return name + "_" + created.Format(FileNameTimeFormat) + noteFileExtension
+}
+
+// RenamedFileName returns the file name for a note called name that keeps
+// fileName's '_YYYY_MM_DD_HH_MM.txt' suffix as written, so the stamp doesn't
+// shift with the time zone. A file name without the suffix is given one
+// from created.
+func RenamedFileName(fileName, name string, created time.Time) string {
+	suffix := noteSuffixRegex.FindString(fileName)
+	if suffix == "" {
+		return NoteFileName(name, created)
+	}
+	return name + suffix
+}
+
+// ValidateNoteName checks that a user-supplied note name is not empty, fits
+// within charLimit, and contains no characters that are unsafe in file names.
+func ValidateNoteName(name string, charLimit int) error {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	if len(name) > charLimit {
+		return fmt.Errorf("name exceeds %d character limit", charLimit)
+	}
+
+	if err := checkForIllegalCharacters(name); err != nil {
+		return fmt.Errorf("invalid characters in note name: %w", err)
+	}
+
+	return nil
+}
+
+func checkForIllegalCharacters(noteName string) error {
+	var illegalCharsFound []rune
+
+	for _, char := range noteName {
+		if strings.ContainsRune(IllegalNameChars, char) {
+			illegalCharsFound = append(illegalCharsFound, char)
+		}
+	}
+
+	if len(illegalCharsFound) > 0 {
+		return fmt.Errorf("name contains illegal characters: %q", string(illegalCharsFound))
+	}
+
+	return nil
+}
+
+// SplitNotePath splits a name given to 'create', such as
+// 'projects/alpha/kickoff', into its folder and note name.
+func SplitNotePath(name string) (folder, noteName string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return CleanFolder(name[:i]), name[i+1:]
+	}
+	return "", name
+}
+
+// CleanFolder normalizes a user-supplied folder path. Surrounding slashes are
+// removed, and "", "." and "/" all refer to the top of the notes directory.
+func CleanFolder(folder string) string {
+	folder = strings.Trim(strings.TrimSpace(folder), "/")
+	if folder == "." {
+		return ""
+	}
+	return folder
+}
+
+// ValidateFolder checks that every part of a slash-separated folder path is
+// a usable folder name. The empty path, meaning the top of the notes
+// directory, is valid.
+func ValidateFolder(folder string) error {
+	if folder == "" {
+		return nil
+	}
+
+	for _, part := range strings.Split(folder, "/") {
+		if part == "" {
+			return fmt.Errorf("folder %q contains an empty part", folder)
+		}
+		if err := checkForIllegalCharacters(part); err != nil {
+			return fmt.Errorf("invalid characters in folder %q: %w", part, err)
+		}
+	}
+	return nil
+}
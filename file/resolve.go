@@ -0,0 +1,119 @@
+package file
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rhysmah/note-app/internal/logger"
+)
+
+const (
+	noteSuffixPattern = `_\d{4}_\d{2}_\d{2}_\d{2}_\d{2}\.txt$`
+
+	// MinIDPrefixLength is the shortest ID prefix accepted when resolving a
+	// note, so that short words aren't mistaken for IDs.
+	MinIDPrefixLength = 4
+	// ShortIDLength is how many characters of an ID are shown in listings.
+	ShortIDLength = 7
+)
+
+var noteSuffixRegex = regexp.MustCompile(noteSuffixPattern)
+
+// DisplayName strips the '_YYYY_MM_DD_HH_MM.txt' suffix added by the create
+// command, returning the name the user originally gave the note.
+func DisplayName(fileName string) string {
+	return noteSuffixRegex.ReplaceAllString(fileName, "")
+}
+
+// ShortID returns the abbreviated form of an ID shown in listings.
+func ShortID(id string) string {
+	if len(id) <= ShortIDLength {
+		return id
+	}
+	return id[:ShortIDLength]
+}
+
+// FindNote locates a single note in notesDir. The query is tried, in order,
+// as a full file name, a display name, a unique ID prefix, and a list index
+// (the number shown by 'list', optionally prefixed with '#'). File and
+// display names may be prefixed with the note's folder, e.g.
+// 'projects/kickoff'. It returns an error listing the candidates if the
+// query matches more than one note.
+func FindNote(logger *logger.Logger, notesDir, query string) (*File, error) {
+	logger.Start(fmt.Sprintf("Resolving note %q...", query))
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("note name cannot be empty")
+	}
+
+	files, err := PrepareNoteFiles(logger, notesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := ResolveNote(files, query)
+	if err != nil {
+		logger.Fail(err.Error())
+		return nil, err
+	}
+
+	logger.Success(fmt.Sprintf("Resolved %q to %q", query, note.Name))
+	return note, nil
+}
+
+// ResolveNote finds a single note among files using the same rules as FindNote.
+func ResolveNote(files []File, query string) (*File, error) {
+	matchers := []func(File) bool{
+		func(f File) bool { return f.Name == query || f.RelPath() == query },
+		func(f File) bool {
+			return DisplayName(f.Name) == query || path.Join(f.Folder, DisplayName(f.Name)) == query
+		},
+		func(f File) bool {
+			return len(query) >= MinIDPrefixLength && strings.HasPrefix(f.ID, strings.ToLower(query))
+		},
+	}
+
+	for _, matches := range matchers {
+		var candidates []File
+		for _, f := range files {
+			if matches(f) {
+				candidates = append(candidates, f)
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return &candidates[0], nil
+		default:
+			return nil, ambiguousNoteError(query, candidates)
+		}
+	}
+
+	if index, err := strconv.Atoi(strings.TrimPrefix(query, "#")); err == nil {
+		for _, f := range files {
+			if f.Index == index {
+				return &f, nil
+			}
+		}
+		return nil, fmt.Errorf("no note at list index %d; there are %d notes", index, len(files))
+	}
+
+	return nil, fmt.Errorf("no note found matching %q", query)
+}
+
+// ambiguousNoteError builds an error listing every candidate for a query so
+// the user can re-run the command with a more specific name or ID.
+func ambiguousNoteError(query string, candidates []File) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%q matches %d notes; use the full file name or a longer ID:", query, len(candidates))
+	for _, c := range candidates {
+		fmt.Fprintf(&sb, "\n  %3d  %s  %s", c.Index, ShortID(c.ID), c.RelPath())
+	}
+	return fmt.Errorf("%s", sb.String())
+}
@@ -0,0 +1,113 @@
+package file
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const tagAllowedChars = "abcdefghijklmnopqrstuvwxyz0123456789-_/"
+
+// NormalizeTag lower-cases and trims a tag, returning an error if it is empty
+// or contains characters that would not survive the front matter format.
+func NormalizeTag(tag string) (string, error) {
+	tag = foldTag(tag)
+
+	if tag == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+
+	for _, char := range tag {
+		if !strings.ContainsRune(tagAllowedChars, char) {
+			return "", fmt.Errorf("tag %q contains illegal character %q; use letters, digits, '-', '_' or '/'", tag, char)
+		}
+	}
+
+	return tag, nil
+}
+
+// foldTag puts a tag in the form it is stored and compared in: trimmed,
+// lower-cased and without a leading '#'.
+func foldTag(tag string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tag)), "#")
+}
+
+// NormalizeTags normalizes every tag and removes duplicates, preserving order.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		n, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, n) {
+			normalized = append(normalized, n)
+		}
+	}
+
+	return normalized, nil
+}
+
+// HasTags reports whether the file carries every one of the given tags.
+func (f File) HasTags(tags ...string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(f.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddTags adds tags that aren't already present and reports which were added.
+func (fm *FrontMatter) AddTags(tags ...string) []string {
+	var added []string
+	for _, tag := range tags {
+		if !slices.Contains(fm.Tags, tag) {
+			fm.Tags = append(fm.Tags, tag)
+			added = append(added, tag)
+		}
+	}
+	return added
+}
+
+// RemoveTags removes any of the given tags and reports which were removed.
+func (fm *FrontMatter) RemoveTags(tags ...string) []string {
+	var removed []string
+	fm.Tags = slices.DeleteFunc(fm.Tags, func(tag string) bool {
+		if slices.Contains(tags, tag) {
+			removed = append(removed, tag)
+			return true
+		}
+		return false
+	})
+	return removed
+}
+
+// ReadNoteForUpdate reads a note's front matter and body, filling in any
+// missing ID, title or creation date so that legacy notes gain a complete
+// front matter block when they are rewritten. A legacy note is given a new
+// random ID in place of the one derived from its path.
+func ReadNoteForUpdate(f *File) (FrontMatter, []byte, error) {
+	fm, body, err := ReadNote(f.FilePath)
+	if err != nil {
+		return FrontMatter{}, nil, err
+	}
+
+	if fm.ID == "" {
+		id, err := NewID()
+		if err != nil {
+			return FrontMatter{}, nil, err
+		}
+		fm.ID = id
+	}
+	if fm.Title == "" {
+		fm.Title = f.Title
+	}
+	if fm.Created.IsZero() {
+		fm.Created = f.DateCreated.Truncate(time.Second)
+	}
+
+	return fm, body, nil
+}
@@ -0,0 +1,412 @@
+// Package config loads and saves the user's persistent note-app settings.
+//
+// Settings live in ~/.note-app/config as TOML-style 'key = value' lines.
+// Blank lines and lines starting with '#' are ignored, and string values may
+// be wrapped in double quotes.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rhysmah/note-app/internal/logger"
+)
+
+const (
+	appDirName     = ".note-app"
+	configFileName = "config"
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	configDirPermissions  = 0755
+	configFilePermissions = 0644
+)
+
+// Keys recognised in the config file.
+const (
+	KeyNotesDir          = "notes_dir"
+	KeyListSortBy        = "list.sort_by"
+	KeyListOrder         = "list.order"
+	KeyNoteNameCharLimit = "create.name_char_limit"
+	KeyHistoryGit        = "history.git"
+	KeyVersionsKeep      = "versions.keep"
+)
+
+// Default values used when a key is not set. list.order defaults to the
+// order matching list.sort_by: DefaultListOrder when sorting by name and
+// DefaultListDateOrder when sorting by a date.
+const (
+	DefaultListSortBy        = "name"
+	DefaultListOrder         = "alph"
+	DefaultListDateOrder     = "new"
+	DefaultNoteNameCharLimit = 50
+	DefaultVersionsKeep      = 20
+)
+
+// Config holds the effective value of every setting. Fields are populated
+// from the config file, falling back to defaults for unset keys.
+type Config struct {
+	NotesDir          string
+	ListSortBy        string
+	ListOrder         string
+	NoteNameCharLimit int
+	HistoryGit        bool
+	VersionsKeep      int
+
+	path   string
+	values map[string]string // raw values as stored in the config file
+}
+
+// setting describes a single config key and how it maps onto Config.
+type setting struct {
+	key         string
+	description string
+	apply       func(cfg *Config, value string) error
+	reset       func(cfg *Config)
+	get         func(cfg *Config) string
+}
+
+var settings = []setting{
+	{
+		key:         KeyNotesDir,
+		description: "Directory where notes are stored",
+		apply:       func(cfg *Config, value string) error { cfg.NotesDir = value; return nil },
+		reset:       func(cfg *Config) { cfg.NotesDir = "" },
+		get:         func(cfg *Config) string { return cfg.NotesDir },
+	},
+	{
+		key:         KeyListSortBy,
+		description: "Default sort field for 'list' (name, ctd, mod)",
+		apply:       func(cfg *Config, value string) error { cfg.ListSortBy = value; return nil },
+		reset:       func(cfg *Config) { cfg.ListSortBy = DefaultListSortBy },
+		get:         func(cfg *Config) string { return cfg.ListSortBy },
+	},
+	{
+		key:         KeyListOrder,
+		description: "Default sort order for 'list' (alph, ralph, new, old); follows list.sort_by if unset",
+		apply:       func(cfg *Config, value string) error { cfg.ListOrder = value; return nil },
+		reset:       func(cfg *Config) { cfg.ListOrder = DefaultListOrder },
+		get:         func(cfg *Config) string { return cfg.ListOrder },
+	},
+	{
+		key:         KeyNoteNameCharLimit,
+		description: "Maximum length of a note name",
+		apply: func(cfg *Config, value string) error {
+			limit, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%q must be a whole number, got %q", KeyNoteNameCharLimit, value)
+			}
+			cfg.NoteNameCharLimit = limit
+			return nil
+		},
+		reset: func(cfg *Config) { cfg.NoteNameCharLimit = DefaultNoteNameCharLimit },
+		get:   func(cfg *Config) string { return strconv.Itoa(cfg.NoteNameCharLimit) },
+	},
+	{
+		key:         KeyHistoryGit,
+		description: "Commit every change to a git repository in the notes directory",
+		apply: func(cfg *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%q must be true or false, got %q", KeyHistoryGit, value)
+			}
+			cfg.HistoryGit = enabled
+			return nil
+		},
+		reset: func(cfg *Config) { cfg.HistoryGit = false },
+		get:   func(cfg *Config) string { return strconv.FormatBool(cfg.HistoryGit) },
+	},
+	{
+		key:         KeyVersionsKeep,
+		description: "Number of snapshots kept per note (0 disables snapshots)",
+		apply: func(cfg *Config, value string) error {
+			keep, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%q must be a whole number, got %q", KeyVersionsKeep, value)
+			}
+			cfg.VersionsKeep = keep
+			return nil
+		},
+		reset: func(cfg *Config) { cfg.VersionsKeep = DefaultVersionsKeep },
+		get:   func(cfg *Config) string { return strconv.Itoa(cfg.VersionsKeep) },
+	},
+}
+
+// Path returns the location of the config file in the user's app directory.
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("couldn't find user's home directory: %w", err)
+	}
+	return filepath.Join(homeDir, appDirName, configFileName), nil
+}
+
+// New returns a Config with every setting at its default value.
+func New(path string) *Config {
+	cfg := &Config{
+		path:   path,
+		values: map[string]string{},
+	}
+	for _, s := range settings {
+		s.reset(cfg)
+	}
+	return cfg
+}
+
+// Load reads the config file. A missing file is not an error; it simply
+// yields a Config with default values.
+//
+// If the file can be read but holds invalid settings, Load returns both an
+// error and a Config in which the invalid values are left at their
+// defaults, so the config commands can still be used to repair the file.
+// Settings are checked in the order of Keys, so when two conflict, such as
+// list.order and list.sort_by, the later one is the one reset. Invalid
+// values are dropped from the file the next time it is saved.
+func Load(logger *logger.Logger) (*Config, error) {
+	configPath, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Start(fmt.Sprintf("Loading config from %q...", configPath))
+	cfg := New(configPath)
+
+	configFile, err := os.Open(configPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Info("No config file found; using defaults")
+			return cfg, nil
+		}
+		logger.Fail(fmt.Sprintf("Failed to open config file: %v", err))
+		return nil, fmt.Errorf("failed to open config file %q: %w", configPath, err)
+	}
+	defer configFile.Close()
+
+	scanner := bufio.NewScanner(configFile)
+	var problems []lineError
+
+	// Known settings are applied once the whole file is read, so that a
+	// later line for the same key wins and rules spanning several keys see
+	// them all.
+	raw := map[string]string{}
+	lines := map[string]int{}
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		key, value, ok, err := parseLine(scanner.Text())
+		if err != nil {
+			logger.Fail(fmt.Sprintf("Invalid config on line %d: %v", lineNum, err))
+			problems = append(problems, lineError{line: lineNum, err: err})
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if _, known := lookup(key); !known {
+			logger.Info(fmt.Sprintf("Ignoring unknown config key %q on line %d", key, lineNum))
+			cfg.values[key] = value
+			continue
+		}
+		raw[key] = value
+		lines[key] = lineNum
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %q: %w", configPath, err)
+	}
+
+	// Set rejects a value that doesn't parse or validate, leaving the
+	// setting at its default and the value out of the file when it is next
+	// saved.
+	for _, s := range settings {
+		value, ok := raw[s.key]
+		if !ok {
+			continue
+		}
+		if err := cfg.Set(s.key, value); err != nil {
+			logger.Fail(fmt.Sprintf("Invalid config on line %d: %v", lines[s.key], err))
+			problems = append(problems, lineError{line: lines[s.key], err: err})
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.SliceStable(problems, func(a, b int) bool { return problems[a].line < problems[b].line })
+		errs := make([]error, len(problems))
+		for i, problem := range problems {
+			errs[i] = problem
+		}
+		return cfg, fmt.Errorf("invalid config in %q: %w", configPath, errors.Join(errs...))
+	}
+
+	logger.Success("Config loaded")
+	return cfg, nil
+}
+
+// lineError is a problem with a line of the config file.
+type lineError struct {
+	line int
+	err  error
+}
+
+func (e lineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.line, e.err)
+}
+
+func (e lineError) Unwrap() error {
+	return e.err
+}
+
+// Keys returns every recognised config key in a stable order.
+func Keys() []string {
+	keys := make([]string, 0, len(settings))
+	for _, s := range settings {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+// Description returns a short explanation of what a key controls.
+func Description(key string) string {
+	if s, ok := lookup(key); ok {
+		return s.description
+	}
+	return ""
+}
+
+// Get returns the effective value of key.
+func (cfg *Config) Get(key string) (string, error) {
+	s, ok := lookup(key)
+	if !ok {
+		return "", unknownKeyError(key)
+	}
+	return s.get(cfg), nil
+}
+
+// IsSet reports whether key has been explicitly set in the config file.
+func (cfg *Config) IsSet(key string) bool {
+	_, ok := cfg.values[key]
+	return ok
+}
+
+// Set validates and stores a new value for key. The change is not written
+// to disk until Save is called.
+func (cfg *Config) Set(key, value string) error {
+	s, ok := lookup(key)
+	if !ok {
+		return unknownKeyError(key)
+	}
+
+	// Apply to a copy first so a failed validation leaves cfg untouched.
+	updated := *cfg
+	updated.values = maps.Clone(cfg.values)
+	updated.values[key] = value
+
+	if err := s.apply(&updated, value); err != nil {
+		return err
+	}
+	updated.applyDependentDefaults()
+
+	if err := NewValidator().Run(&updated); err != nil {
+		return err
+	}
+
+	*cfg = updated
+	return nil
+}
+
+// Unset removes key from the config file, restoring its default value.
+func (cfg *Config) Unset(key string) error {
+	s, ok := lookup(key)
+	if !ok {
+		return unknownKeyError(key)
+	}
+
+	s.reset(cfg)
+	delete(cfg.values, key)
+	cfg.applyDependentDefaults()
+	return nil
+}
+
+// applyDependentDefaults fills in defaults that depend on other settings:
+// an unset list.order follows list.sort_by, so that setting only the sort
+// field never leaves an order that doesn't fit it.
+func (cfg *Config) applyDependentDefaults() {
+	if cfg.IsSet(KeyListOrder) {
+		return
+	}
+
+	cfg.ListOrder = DefaultListOrder
+	if cfg.ListSortBy != DefaultListSortBy {
+		cfg.ListOrder = DefaultListDateOrder
+	}
+}
+
+// Save writes every explicitly set key back to the config file.
+func (cfg *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(cfg.path), configDirPermissions); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	keys := make([]string, 0, len(cfg.values))
+	for key := range cfg.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("# note-app configuration. Manage with 'note-app config'.\n")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "%s = %s\n", key, strconv.Quote(cfg.values[key]))
+	}
+
+	if err := os.WriteFile(cfg.path, []byte(sb.String()), configFilePermissions); err != nil {
+		return fmt.Errorf("failed to write config file %q: %w", cfg.path, err)
+	}
+	return nil
+}
+
+// parseLine splits a 'key = value' line. It returns ok == false for blank
+// lines and comments.
+func parseLine(line string) (key, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+
+	key, value, found := strings.Cut(line, "=")
+	if !found {
+		return "", "", false, fmt.Errorf("expected 'key = value', got %q", line)
+	}
+
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", "", false, fmt.Errorf("invalid quoted value for %q: %w", key, err)
+		}
+		value = unquoted
+	}
+
+	return key, value, true, nil
+}
+
+func lookup(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func unknownKeyError(key string) error {
+	return fmt.Errorf("unknown config key %q. Valid keys: %s", key, strings.Join(Keys(), ", "))
+}
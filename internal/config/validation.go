@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rhysmah/note-app/validator"
+)
+
+const (
+	maxNoteNameCharLimit = 200
+	maxVersionsKeep      = 1000
+)
+
+// These mirror the sort fields and orders accepted by the list command.
+var (
+	validSortFields = []string{"name", "ctd", "mod"}
+	validSortOrders = []string{"alph", "ralph", "new", "old"}
+
+	nameSortOrders = []string{"alph", "ralph"}
+	dateSortOrders = []string{"new", "old"}
+)
+
+// NewValidator creates a validator with a predefined set of validation rules.
+func NewValidator() *validator.Validator[Config] {
+	return &validator.Validator[Config]{
+		Rules: []validator.ValidationRule[Config]{
+			validateListSortBy,
+			validateListOrder,
+			validateListSortCombination,
+			validateNoteNameCharLimit,
+			validateVersionsKeep,
+		},
+	}
+}
+
+// validateListSortBy verifies the default sort field is one the list command accepts.
+func validateListSortBy(cfg *Config) error {
+	if !slices.Contains(validSortFields, cfg.ListSortBy) {
+		return fmt.Errorf("invalid %s %q. Valid values: %s",
+			KeyListSortBy, cfg.ListSortBy, strings.Join(validSortFields, ", "))
+	}
+	return nil
+}
+
+// validateListOrder verifies the default sort order is one the list command accepts.
+func validateListOrder(cfg *Config) error {
+	if !slices.Contains(validSortOrders, cfg.ListOrder) {
+		return fmt.Errorf("invalid %s %q. Valid values: %s",
+			KeyListOrder, cfg.ListOrder, strings.Join(validSortOrders, ", "))
+	}
+	return nil
+}
+
+// validateListSortCombination ensures the default order suits the default
+// sort field, as the list command requires: alphabetical orders for names
+// and newest/oldest for dates.
+func validateListSortCombination(cfg *Config) error {
+	orders := dateSortOrders
+	if cfg.ListSortBy == "name" {
+		orders = nameSortOrders
+	}
+
+	if !slices.Contains(orders, cfg.ListOrder) {
+		return fmt.Errorf("%s %q cannot be used with %s %q. Use one of %s, or unset %s to follow %s",
+			KeyListOrder, cfg.ListOrder, KeyListSortBy, cfg.ListSortBy,
+			strings.Join(orders, ", "), KeyListOrder, KeyListSortBy)
+	}
+	return nil
+}
+
+// validateNoteNameCharLimit keeps the name limit within sensible file name lengths.
+func validateNoteNameCharLimit(cfg *Config) error {
+	if cfg.NoteNameCharLimit < 1 || cfg.NoteNameCharLimit > maxNoteNameCharLimit {
+		return fmt.Errorf("%s must be between 1 and %d, got %d",
+			KeyNoteNameCharLimit, maxNoteNameCharLimit, cfg.NoteNameCharLimit)
+	}
+	return nil
+}
+
+// validateVersionsKeep caps how many snapshots are kept for each note.
+func validateVersionsKeep(cfg *Config) error {
+	if cfg.VersionsKeep < 0 || cfg.VersionsKeep > maxVersionsKeep {
+		return fmt.Errorf("%s must be between 0 and %d, got %d",
+			KeyVersionsKeep, maxVersionsKeep, cfg.VersionsKeep)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package editor launches the user's preferred text editor.
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	defaultUnixEditor    = "vi"
+	defaultWindowsEditor = "notepad"
+)
+
+// Command returns the editor command and its arguments, checking $VISUAL,
+// then $EDITOR, then falling back to a platform default.
+func Command() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return []string{defaultWindowsEditor}
+	}
+	return []string{defaultUnixEditor}
+}
+
+// Open opens filePath in the user's editor and waits for it to exit.
+// The editor inherits the terminal so interactive editors work as expected.
+func Open(filePath string) error {
+	command := Command()
+	args := append(command[1:], filePath)
+
+	editorCmd := exec.Command(command[0], args...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", command[0], err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/rhysmah/note-app/internal/logger"
 )
@@ -12,21 +13,77 @@ const (
 	// Octal: 4 = read, 2 = write, 1 = execute
 	dirPermissions  int    = 0755
 	defaultNotesDir string = "/notes"
+	appDirName      string = ".note-app"
+	indexDirName    string = "index"
+	trashDirName    string = "trash"
+	versionsDirName string = "versions"
+	notebooksDir    string = "notebooks"
+
+	// NotesDirEnvVar overrides the configured notes directory.
+	NotesDirEnvVar = "NOTE_APP_DIR"
 )
 
+// NotesDirSource identifies where the notes directory location came from.
+type NotesDirSource int
+
+// Sources are listed from lowest to highest precedence.
+const (
+	SourceDefault NotesDirSource = iota
+	SourceConfig
+	SourceNotebook
+	SourceEnv
+	SourceNotebookFlag
+	SourceFlag
+)
+
+func (s NotesDirSource) String() string {
+	values := [...]string{
+		"default",
+		"config file",
+		"notebook in use",
+		"environment variable " + NotesDirEnvVar,
+		"--notebook flag",
+		"--notes-dir flag",
+	}
+
+	if s < SourceDefault || s > SourceFlag {
+		return "unknown"
+	}
+
+	return values[s]
+}
+
+// NotesDirOverrides holds notes directory locations supplied by the user.
+// Empty fields are skipped; the environment variable is read separately.
+// NotebookFlag is the directory of the notebook named by --notebook, and
+// Notebook that of the notebook selected with 'notebook use'.
+type NotesDirOverrides struct {
+	Flag         string
+	NotebookFlag string
+	Notebook     string
+	Config       string
+}
+
 type DirectoryManager struct {
-	logger   *logger.Logger
-	homeDir  string
-	notesDir string
+	logger         *logger.Logger
+	overrides      NotesDirOverrides
+	homeDir        string
+	notesDir       string
+	notesDirSource NotesDirSource
 }
 
-func NewDirectoryManager(logger *logger.Logger) (*DirectoryManager, error) {
+// NewDirectoryManager resolves and creates the notes directory. The location
+// is taken from, in order: the --notes-dir flag, the --notebook flag, the
+// NOTE_APP_DIR environment variable, the notebook in use, the config file,
+// and finally $HOME/notes.
+func NewDirectoryManager(logger *logger.Logger, overrides NotesDirOverrides) (*DirectoryManager, error) {
 	if logger == nil {
 		return nil, fmt.Errorf("logger cannot be nil")
 	}
 
 	dm := &DirectoryManager{
-		logger: logger,
+		logger:    logger,
+		overrides: overrides,
 	}
 
 	if err := dm.initialize(); err != nil {
@@ -60,6 +117,45 @@ func (dm *DirectoryManager) NotesDir() string {
 	return dm.notesDir
 }
 
+// NotesDirSource reports which setting determined the notes directory.
+func (dm *DirectoryManager) NotesDirSource() NotesDirSource {
+	return dm.notesDirSource
+}
+
+// AppDir returns the application's own directory, which holds logs and config.
+func (dm *DirectoryManager) AppDir() string {
+	return filepath.Join(dm.homeDir, appDirName)
+}
+
+// IndexDir returns the directory holding the persistent search index.
+func (dm *DirectoryManager) IndexDir() string {
+	return filepath.Join(dm.AppDir(), indexDirName)
+}
+
+// TrashDir returns the directory deleted notes are moved into.
+func (dm *DirectoryManager) TrashDir() string {
+	return filepath.Join(dm.AppDir(), trashDirName)
+}
+
+// NotebookDir returns where a new notebook is stored unless the user picks
+// another directory: $HOME/notebooks/<name>.
+func (dm *DirectoryManager) NotebookDir(name string) string {
+	return filepath.Join(dm.homeDir, notebooksDir, name)
+}
+
+// CreateDir creates dir and any missing parents.
+func (dm *DirectoryManager) CreateDir(dir string) error {
+	if err := os.MkdirAll(dir, os.FileMode(dirPermissions)); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", dir, err)
+	}
+	return nil
+}
+
+// VersionsDir returns the directory holding note snapshots.
+func (dm *DirectoryManager) VersionsDir() string {
+	return filepath.Join(dm.AppDir(), versionsDirName)
+}
+
 func (dm *DirectoryManager) confirmUserHomeDirectory() (string, error) {
 	dm.logger.Start("Looking up user home directory...")
 
@@ -77,10 +173,15 @@ func (dm *DirectoryManager) confirmUserHomeDirectory() (string, error) {
 func (dm *DirectoryManager) confirmNotesDirectory() (string, error) {
 	dm.logger.Start("Setting up notes directory...")
 
-	notesDirPath := filepath.Join(dm.homeDir, defaultNotesDir)
-	dm.logger.Info(fmt.Sprintf("Target notes directory path: %s", notesDirPath))
+	notesDirPath, source, err := dm.resolveNotesDirectory(SourceFlag)
+	if err != nil {
+		dm.logger.Fail(err.Error())
+		return "", err
+	}
+	dm.notesDirSource = source
+	dm.logger.Info(fmt.Sprintf("Target notes directory path: %s (from %s)", notesDirPath, source))
 
-	err := os.MkdirAll(notesDirPath, os.FileMode(dirPermissions))
+	err = os.MkdirAll(notesDirPath, os.FileMode(dirPermissions))
 	if err != nil {
 		errMsg := fmt.Sprintf("Directory creation failed: %v", err)
 		dm.logger.Fail(errMsg)
@@ -90,3 +191,54 @@ func (dm *DirectoryManager) confirmNotesDirectory() (string, error) {
 	dm.logger.Success(fmt.Sprintf("Notes directory ready at: %s", notesDirPath))
 	return notesDirPath, nil
 }
+
+// DefaultNotebookDir returns the notes directory used when no notebook is
+// selected: the one from the config file, or $HOME/notes.
+func (dm *DirectoryManager) DefaultNotebookDir() (string, error) {
+	notesDirPath, _, err := dm.resolveNotesDirectory(SourceConfig)
+	return notesDirPath, err
+}
+
+// resolveNotesDirectory picks the notes directory from the highest-precedence
+// source that has a value, ignoring sources above maxSource, and returns it
+// as an absolute path.
+func (dm *DirectoryManager) resolveNotesDirectory(maxSource NotesDirSource) (string, NotesDirSource, error) {
+	candidates := []struct {
+		path   string
+		source NotesDirSource
+	}{
+		{dm.overrides.Flag, SourceFlag},
+		{dm.overrides.NotebookFlag, SourceNotebookFlag},
+		{os.Getenv(NotesDirEnvVar), SourceEnv},
+		{dm.overrides.Notebook, SourceNotebook},
+		{dm.overrides.Config, SourceConfig},
+	}
+
+	for _, candidate := range candidates {
+		if candidate.source > maxSource || strings.TrimSpace(candidate.path) == "" {
+			continue
+		}
+
+		notesDirPath, err := dm.AbsolutePath(candidate.path)
+		if err != nil {
+			return "", candidate.source, fmt.Errorf("invalid notes directory from %s: %w", candidate.source, err)
+		}
+		return notesDirPath, candidate.source, nil
+	}
+
+	return filepath.Join(dm.homeDir, defaultNotesDir), SourceDefault, nil
+}
+
+// AbsolutePath expands a leading '~' to the user's home directory and makes
+// the path absolute.
+func (dm *DirectoryManager) AbsolutePath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+
+	if path == "~" {
+		path = dm.homeDir
+	} else if strings.HasPrefix(path, "~/") {
+		path = filepath.Join(dm.homeDir, path[2:])
+	}
+
+	return filepath.Abs(path)
+}
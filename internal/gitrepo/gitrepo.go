@@ -0,0 +1,191 @@
+// Package gitrepo records note changes in a local git repository by shelling
+// out to the git command.
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	gitBinary = "git"
+
+	// Used when the user has no git identity configured, so commits never fail.
+	fallbackUserName  = "note-app"
+	fallbackUserEmail = "note-app@localhost"
+
+	// Separates fields in 'git log' output.
+	fieldSeparator = "\x1f"
+)
+
+// Revision is a commit that touched a note.
+type Revision struct {
+	Hash    string
+	Date    time.Time
+	Subject string
+	Path    string // the note's path relative to the repository at this revision
+}
+
+// ShortHash returns the abbreviated commit hash shown to users.
+func (r Revision) ShortHash() string {
+	if len(r.Hash) <= 8 {
+		return r.Hash
+	}
+	return r.Hash[:8]
+}
+
+// Repo is a git repository rooted at the notes directory.
+type Repo struct {
+	dir string
+}
+
+// Open returns the repository in dir, initializing one if it doesn't exist.
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath(gitBinary); err != nil {
+		return nil, errors.New("git is not installed or not on your PATH")
+	}
+
+	repo := &Repo{dir: dir}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if _, err := repo.run("init", "--quiet"); err != nil {
+			return nil, fmt.Errorf("failed to initialize git repository: %w", err)
+		}
+	}
+
+	return repo, nil
+}
+
+// Commit stages the files at paths, relative to the repository, and commits
+// them with message. Paths that no longer exist are recorded as removed.
+// Nothing else in the repository is staged or committed. It returns false if
+// none of the paths changed.
+func (r *Repo) Commit(message string, paths ...string) (bool, error) {
+	if len(paths) == 0 {
+		return false, nil
+	}
+
+	var present []string
+	for _, p := range paths {
+		if _, err := os.Stat(filepath.Join(r.dir, p)); err == nil {
+			present = append(present, p)
+		}
+	}
+	if len(present) > 0 {
+		if _, err := r.run(append([]string{"add", "--"}, present...)...); err != nil {
+			return false, fmt.Errorf("failed to stage changes: %w", err)
+		}
+	}
+
+	// Only paths git knows about can be committed; a removed note that was
+	// never committed has nothing to record.
+	status, err := r.run(append([]string{"status", "--porcelain", "-z", "--no-renames", "--"}, paths...)...)
+	if err != nil {
+		return false, fmt.Errorf("failed to check repository status: %w", err)
+	}
+
+	var changed []string
+	for _, entry := range strings.Split(status, "\x00") {
+		// Each entry is a two-letter status, a space and the path.
+		if len(entry) > 3 && !strings.HasPrefix(entry, "??") {
+			changed = append(changed, entry[3:])
+		}
+	}
+	if len(changed) == 0 {
+		return false, nil
+	}
+
+	// Committing the paths commits their working tree state, removals
+	// included, leaving anything else that is staged alone.
+	args := append(r.identityArgs(), "commit", "--quiet", "--message", message, "--")
+	if _, err := r.run(append(args, changed...)...); err != nil {
+		return false, fmt.Errorf("failed to commit changes: %w", err)
+	}
+	return true, nil
+}
+
+// Log returns the revisions that touched the file at relPath, newest first,
+// following it across renames.
+func (r *Repo) Log(relPath string) ([]Revision, error) {
+	format := strings.Join([]string{"%H", "%aI", "%s"}, fieldSeparator)
+
+	output, err := r.run("log", "--follow", "--name-only", "--format="+fieldSeparator+format, "--", relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var revisions []Revision
+
+	// Each commit is a header line starting with the separator followed by
+	// the file name(s) it touched.
+	var current *Revision
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, fieldSeparator) {
+			fields := strings.SplitN(strings.TrimPrefix(line, fieldSeparator), fieldSeparator, 3)
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("unexpected git log output: %q", line)
+			}
+
+			date, err := time.Parse(time.RFC3339, fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("unexpected commit date %q: %w", fields[1], err)
+			}
+
+			revisions = append(revisions, Revision{Hash: fields[0], Date: date, Subject: fields[2]})
+			current = &revisions[len(revisions)-1]
+			continue
+		}
+
+		if current != nil && current.Path == "" {
+			current.Path = line
+		}
+	}
+
+	return revisions, nil
+}
+
+// Show returns the content of relPath as it was at revision hash.
+func (r *Repo) Show(hash, relPath string) ([]byte, error) {
+	output, err := r.run("show", hash+":"+filepath.ToSlash(relPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q at %s: %w", relPath, hash, err)
+	}
+	return []byte(output), nil
+}
+
+// identityArgs supplies a fallback author when git has none configured.
+func (r *Repo) identityArgs() []string {
+	if email, err := r.run("config", "user.email"); err == nil && strings.TrimSpace(email) != "" {
+		return nil
+	}
+	return []string{"-c", "user.name=" + fallbackUserName, "-c", "user.email=" + fallbackUserEmail}
+}
+
+// run executes git in the repository directory and returns its stdout.
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command(gitBinary, args...)
+	cmd.Dir = r.dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
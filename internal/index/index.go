@@ -0,0 +1,233 @@
+// Package index maintains a persistent inverted index over the notes
+// directory so searches don't need to read every note.
+//
+// The index is stored with encoding/gob under ~/.note-app/index/, one file
+// per notes directory. It is updated incrementally: a note is only
+// re-tokenized when its modification time differs from the indexed one.
+package index
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+	"github.com/rhysmah/note-app/internal/logger"
+)
+
+const (
+	// formatVersion is bumped whenever the on-disk layout changes; older
+	// indexes are discarded and rebuilt.
+	formatVersion = 2
+
+	indexFileSuffix = ".gob"
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	indexDirPermissions = 0755
+)
+
+// Document is a single indexed note.
+type Document struct {
+	Path    string
+	Name    string
+	ModTime time.Time
+	Length  int      // number of tokens in the note
+	Terms   []string // distinct terms, used to remove the note's postings
+}
+
+// Index maps terms to the positions at which they occur in each note.
+type Index struct {
+	Version   int
+	NotesDir  string
+	UpdatedAt time.Time
+	Docs      map[string]*Document
+	Postings  map[string]map[string][]int // term -> note path -> positions
+
+	path string
+}
+
+// UpdateStats reports what changed during an incremental update.
+type UpdateStats struct {
+	Added     int
+	Updated   int
+	Removed   int
+	Unchanged int
+}
+
+// Path returns the index file used for notesDir inside indexDir.
+func Path(indexDir, notesDir string) string {
+	sum := sha256.Sum256([]byte(notesDir))
+	return filepath.Join(indexDir, hex.EncodeToString(sum[:8])+indexFileSuffix)
+}
+
+// New returns an empty index for notesDir that will be saved inside indexDir.
+func New(indexDir, notesDir string) *Index {
+	return &Index{
+		Version:  formatVersion,
+		NotesDir: notesDir,
+		Docs:     map[string]*Document{},
+		Postings: map[string]map[string][]int{},
+		path:     Path(indexDir, notesDir),
+	}
+}
+
+// Load reads the index for notesDir from indexDir. A missing or outdated
+// index yields an empty one, which the next Update will fill.
+func Load(logger *logger.Logger, indexDir, notesDir string) (*Index, error) {
+	idx := New(indexDir, notesDir)
+	logger.Start(fmt.Sprintf("Loading search index %q...", idx.path))
+
+	indexFile, err := os.Open(idx.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Info("No search index found; starting a new one")
+			return idx, nil
+		}
+		logger.Fail(fmt.Sprintf("Failed to open search index: %v", err))
+		return nil, fmt.Errorf("failed to open search index: %w", err)
+	}
+	defer indexFile.Close()
+
+	var stored Index
+	if err := gob.NewDecoder(indexFile).Decode(&stored); err != nil {
+		logger.Info(fmt.Sprintf("Search index is unreadable, rebuilding: %v", err))
+		return idx, nil
+	}
+
+	if stored.Version != formatVersion || stored.NotesDir != notesDir {
+		logger.Info("Search index is outdated, rebuilding")
+		return idx, nil
+	}
+
+	stored.path = idx.path
+	logger.Success(fmt.Sprintf("Loaded search index with %d notes", len(stored.Docs)))
+	return &stored, nil
+}
+
+// Save writes the index to disk atomically.
+func (idx *Index) Save() error {
+	if err := os.MkdirAll(filepath.Dir(idx.path), indexDirPermissions); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(idx.path), "index-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create index file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(idx); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), idx.path); err != nil {
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+	return nil
+}
+
+// Update brings the index in line with files, re-indexing notes whose
+// modification time changed and dropping notes that no longer exist.
+func (idx *Index) Update(logger *logger.Logger, files []file.File) (UpdateStats, error) {
+	logger.Start(fmt.Sprintf("Updating search index for %d notes...", len(files)))
+
+	var stats UpdateStats
+	seen := make(map[string]bool, len(files))
+
+	for _, f := range files {
+		seen[f.FilePath] = true
+
+		doc, exists := idx.Docs[f.FilePath]
+		if exists && doc.ModTime.Equal(f.DateModified) {
+			stats.Unchanged++
+			continue
+		}
+
+		if err := idx.indexFile(f); err != nil {
+			logger.Fail(fmt.Sprintf("Failed to index %q: %v", f.Name, err))
+			return stats, err
+		}
+
+		if exists {
+			stats.Updated++
+		} else {
+			stats.Added++
+		}
+	}
+
+	for path := range idx.Docs {
+		if !seen[path] {
+			idx.removeDocument(path)
+			stats.Removed++
+		}
+	}
+
+	idx.UpdatedAt = time.Now()
+	logger.Success(fmt.Sprintf("Search index updated: %d added, %d updated, %d removed, %d unchanged",
+		stats.Added, stats.Updated, stats.Removed, stats.Unchanged))
+	return stats, nil
+}
+
+// TermCount returns the number of distinct terms in the index.
+func (idx *Index) TermCount() int {
+	return len(idx.Postings)
+}
+
+// indexFile tokenizes a note's body and replaces any existing postings for
+// it. Front matter is left out, so only what was written in the note matches.
+func (idx *Index) indexFile(f file.File) error {
+	_, body, err := file.ReadNote(f.FilePath)
+	if err != nil {
+		return err
+	}
+
+	idx.removeDocument(f.FilePath)
+
+	tokens := Tokenize(string(body))
+	doc := &Document{
+		Path:    f.FilePath,
+		Name:    f.RelPath(),
+		ModTime: f.DateModified,
+		Length:  len(tokens),
+	}
+
+	for position, term := range tokens {
+		postings, ok := idx.Postings[term]
+		if !ok {
+			postings = map[string][]int{}
+			idx.Postings[term] = postings
+		}
+		if _, ok := postings[f.FilePath]; !ok {
+			doc.Terms = append(doc.Terms, term)
+		}
+		postings[f.FilePath] = append(postings[f.FilePath], position)
+	}
+
+	idx.Docs[f.FilePath] = doc
+	return nil
+}
+
+// removeDocument deletes a note and all of its postings from the index.
+func (idx *Index) removeDocument(path string) {
+	doc, ok := idx.Docs[path]
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.Terms {
+		delete(idx.Postings[term], path)
+		if len(idx.Postings[term]) == 0 {
+			delete(idx.Postings, term)
+		}
+	}
+	delete(idx.Docs, path)
+}
@@ -0,0 +1,228 @@
+package index
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BM25 tuning parameters, using the commonly recommended defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type clauseKind int
+
+const (
+	termClause clauseKind = iota
+	phraseClause
+	prefixClause
+)
+
+// clause is one part of a query: a single term, a quoted phrase, or a
+// prefix ending in '*'. Every clause must match for a note to be returned.
+type clause struct {
+	kind  clauseKind
+	terms []string
+}
+
+// Result is a note matching a query, with its BM25 relevance score.
+type Result struct {
+	Doc   *Document
+	Score float64
+}
+
+// Tokenize lower-cases text and splits it into terms made of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Search runs a query against the index and returns matching notes ranked
+// by BM25 score, highest first.
+//
+// Queries are made of space-separated clauses, all of which must match:
+//
+//	budget            a single term
+//	"quarterly plan"  an exact phrase
+//	proj*             any term starting with 'proj'
+func (idx *Index) Search(query string) ([]Result, error) {
+	clauses, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates map[string]bool
+	var scoringTerms []string
+
+	for _, c := range clauses {
+		matches, terms := idx.matchClause(c)
+		scoringTerms = append(scoringTerms, terms...)
+
+		if candidates == nil {
+			candidates = matches
+			continue
+		}
+		for path := range candidates {
+			if !matches[path] {
+				delete(candidates, path)
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(candidates))
+	for path := range candidates {
+		doc := idx.Docs[path]
+		results = append(results, Result{Doc: doc, Score: idx.score(doc, scoringTerms)})
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Doc.Name < results[b].Doc.Name
+	})
+
+	return results, nil
+}
+
+// parseQuery splits a query into clauses, honouring double-quoted phrases.
+func parseQuery(query string) ([]clause, error) {
+	var clauses []clause
+
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated phrase in query %q", query)
+			}
+			if terms := Tokenize(rest[1 : end+1]); len(terms) > 0 {
+				clauses = append(clauses, clause{kind: phraseClause, terms: terms})
+			}
+			rest = strings.TrimSpace(rest[end+2:])
+			continue
+		}
+
+		word, remaining, _ := strings.Cut(rest, " ")
+		rest = strings.TrimSpace(remaining)
+
+		if strings.HasSuffix(word, "*") {
+			if terms := Tokenize(strings.TrimSuffix(word, "*")); len(terms) == 1 {
+				clauses = append(clauses, clause{kind: prefixClause, terms: terms})
+				continue
+			}
+		}
+
+		for _, term := range Tokenize(word) {
+			clauses = append(clauses, clause{kind: termClause, terms: []string{term}})
+		}
+	}
+
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("query %q contains no searchable terms", query)
+	}
+	return clauses, nil
+}
+
+// matchClause returns the notes matching a clause and the index terms that
+// should contribute to their score.
+func (idx *Index) matchClause(c clause) (map[string]bool, []string) {
+	matches := map[string]bool{}
+
+	switch c.kind {
+	case prefixClause:
+		var terms []string
+		for term, postings := range idx.Postings {
+			if !strings.HasPrefix(term, c.terms[0]) {
+				continue
+			}
+			terms = append(terms, term)
+			for path := range postings {
+				matches[path] = true
+			}
+		}
+		return matches, terms
+
+	case phraseClause:
+		for path := range idx.Postings[c.terms[0]] {
+			if idx.containsPhrase(path, c.terms) {
+				matches[path] = true
+			}
+		}
+		return matches, c.terms
+
+	default:
+		for path := range idx.Postings[c.terms[0]] {
+			matches[path] = true
+		}
+		return matches, c.terms
+	}
+}
+
+// containsPhrase reports whether terms appear consecutively in the note.
+func (idx *Index) containsPhrase(path string, terms []string) bool {
+	positionSets := make([]map[int]bool, len(terms))
+	for i, term := range terms {
+		positions := idx.Postings[term][path]
+		if len(positions) == 0 {
+			return false
+		}
+		positionSets[i] = make(map[int]bool, len(positions))
+		for _, p := range positions {
+			positionSets[i][p] = true
+		}
+	}
+
+	for start := range positionSets[0] {
+		matched := true
+		for offset := 1; offset < len(terms); offset++ {
+			if !positionSets[offset][start+offset] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// score computes the BM25 score of a note for the given terms.
+func (idx *Index) score(doc *Document, terms []string) float64 {
+	docCount := float64(len(idx.Docs))
+	avgLength := idx.averageLength()
+	score := 0.0
+
+	for _, term := range terms {
+		postings := idx.Postings[term]
+		tf := float64(len(postings[doc.Path]))
+		if tf == 0 {
+			continue
+		}
+
+		df := float64(len(postings))
+		idf := math.Log(1 + (docCount-df+0.5)/(df+0.5))
+		norm := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(doc.Length)/avgLength))
+		score += idf * norm
+	}
+
+	return score
+}
+
+func (idx *Index) averageLength() float64 {
+	if len(idx.Docs) == 0 {
+		return 1
+	}
+
+	total := 0
+	for _, doc := range idx.Docs {
+		total += doc.Length
+	}
+	return max(1, float64(total)/float64(len(idx.Docs)))
+}
@@ -0,0 +1,201 @@
+// Package notebook keeps the registry of named notes directories.
+//
+// The registry lives in ~/.note-app/notebooks.json and records each
+// notebook's directory along with the notebook selected by 'notebook use'.
+// The default notebook is the notes directory chosen by the config file or
+// $HOME/notes and is never stored in the registry.
+package notebook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/rhysmah/note-app/internal/logger"
+)
+
+const (
+	appDirName       = ".note-app"
+	registryFileName = "notebooks.json"
+
+	// DefaultName refers to the notes directory used when no notebook is selected.
+	DefaultName = "default"
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	registryDirPermissions  = 0755
+	registryFilePermissions = 0644
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Notebook is a named notes directory.
+type Notebook struct {
+	Name string
+	Dir  string
+}
+
+// Registry records every named notebook and which one is in use.
+type Registry struct {
+	Current   string            `json:"current,omitempty"`
+	Notebooks map[string]string `json:"notebooks"`
+
+	path string
+}
+
+// Path returns the location of the registry file in the user's app directory.
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("couldn't find user's home directory: %w", err)
+	}
+	return filepath.Join(homeDir, appDirName, registryFileName), nil
+}
+
+// Load reads the registry. A missing file is not an error; it yields an
+// empty registry using the default notebook.
+func Load(logger *logger.Logger) (*Registry, error) {
+	registryPath, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Start(fmt.Sprintf("Loading notebooks from %q...", registryPath))
+	registry := &Registry{Notebooks: map[string]string{}, path: registryPath}
+
+	data, err := os.ReadFile(registryPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Info("No notebooks registered; using the default notebook")
+			return registry, nil
+		}
+		logger.Fail(fmt.Sprintf("Failed to read notebooks: %v", err))
+		return nil, fmt.Errorf("failed to read notebooks file %q: %w", registryPath, err)
+	}
+
+	if err := json.Unmarshal(data, registry); err != nil {
+		logger.Fail(fmt.Sprintf("Invalid notebooks file: %v", err))
+		return nil, fmt.Errorf("invalid notebooks file %q: %w", registryPath, err)
+	}
+	if registry.Notebooks == nil {
+		registry.Notebooks = map[string]string{}
+	}
+
+	logger.Success(fmt.Sprintf("Loaded %d notebooks", len(registry.Notebooks)))
+	return registry, nil
+}
+
+// CurrentName returns the name of the notebook selected with 'notebook use'.
+func (r *Registry) CurrentName() string {
+	if r.Current == "" {
+		return DefaultName
+	}
+	return r.Current
+}
+
+// Lookup returns the directory of a registered notebook.
+func (r *Registry) Lookup(name string) (string, error) {
+	dir, ok := r.Notebooks[name]
+	if !ok {
+		return "", unknownNotebookError(name)
+	}
+	return dir, nil
+}
+
+// List returns every registered notebook sorted by name. The default
+// notebook is not included.
+func (r *Registry) List() []Notebook {
+	notebooks := make([]Notebook, 0, len(r.Notebooks))
+	for name, dir := range r.Notebooks {
+		notebooks = append(notebooks, Notebook{Name: name, Dir: dir})
+	}
+	sort.Slice(notebooks, func(a, b int) bool {
+		return notebooks[a].Name < notebooks[b].Name
+	})
+	return notebooks
+}
+
+// Add registers a notebook stored in dir, which must be an absolute path.
+func (r *Registry) Add(name, dir string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if _, exists := r.Notebooks[name]; exists {
+		return fmt.Errorf("notebook %q already exists", name)
+	}
+	for existing, existingDir := range r.Notebooks {
+		if existingDir == dir {
+			return fmt.Errorf("notebook %q already uses %q", existing, dir)
+		}
+	}
+
+	r.Notebooks[name] = dir
+	return nil
+}
+
+// Remove unregisters a notebook. Its directory and notes are left in place.
+// If it was in use, the default notebook is selected instead.
+func (r *Registry) Remove(name string) error {
+	if name == DefaultName {
+		return fmt.Errorf("the %q notebook cannot be removed", DefaultName)
+	}
+	if _, exists := r.Notebooks[name]; !exists {
+		return unknownNotebookError(name)
+	}
+
+	delete(r.Notebooks, name)
+	if r.Current == name {
+		r.Current = ""
+	}
+	return nil
+}
+
+// Use selects the notebook that commands operate on by default.
+func (r *Registry) Use(name string) error {
+	if name == DefaultName {
+		r.Current = ""
+		return nil
+	}
+	if _, exists := r.Notebooks[name]; !exists {
+		return unknownNotebookError(name)
+	}
+
+	r.Current = name
+	return nil
+}
+
+// Save writes the registry back to disk.
+func (r *Registry) Save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), registryDirPermissions); err != nil {
+		return fmt.Errorf("failed to create notebooks directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode notebooks: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, data, registryFilePermissions); err != nil {
+		return fmt.Errorf("failed to write notebooks file %q: %w", r.path, err)
+	}
+	return nil
+}
+
+// ValidateName checks that a notebook name is usable as a flag value and a
+// directory name.
+func ValidateName(name string) error {
+	if name == DefaultName {
+		return fmt.Errorf("%q is reserved for the default notebook", DefaultName)
+	}
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid notebook name %q: use letters, digits, '-' and '_', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+func unknownNotebookError(name string) error {
+	return fmt.Errorf("no notebook named %q; see 'note-app notebook list'", name)
+}
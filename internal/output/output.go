@@ -0,0 +1,190 @@
+// Package output serializes notes for other programs to consume, as
+// selected with the global --output flag.
+//
+// Every format carries the same fields, described by SchemaVersion 1:
+//
+//	notebook  name of the notebook holding the note ("" if unknown)
+//	index     the note's number, usable in place of its name
+//	id        the note's ID from its front matter
+//	name      path relative to the notes directory, as shown by list
+//	path      absolute path of the note file
+//	title     the note's title
+//	created   creation time, RFC 3339 to the second
+//	modified  last modification time, RFC 3339 to the second
+//	size      size in bytes
+//	tags      tags; a list in JSON, space-separated in CSV and TSV
+//	words     number of words in the body, excluding front matter
+//
+// JSON is a single object {"schema_version": 1, "notes": [...]}. NDJSON
+// writes one note object per line, each with its own "schema_version".
+// CSV and TSV start with a header row naming the fields in the order above.
+// Fields are only ever added within a schema version, at the end so CSV and
+// TSV columns keep their positions; renaming or removing one bumps it.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+// SchemaVersion identifies the layout of the serialized notes.
+const SchemaVersion = 1
+
+// Format is a way of writing notes out.
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatTSV    Format = "tsv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Formats lists every supported format, the human-readable table first.
+var Formats = []Format{FormatTable, FormatJSON, FormatCSV, FormatTSV, FormatNDJSON}
+
+// ParseFormat returns the Format named by s.
+func ParseFormat(s string) (Format, error) {
+	for _, format := range Formats {
+		if Format(strings.ToLower(strings.TrimSpace(s))) == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format %q. Valid formats: %s", s, AvailableFormats())
+}
+
+// AvailableFormats returns a comma-separated string of valid formats.
+func AvailableFormats() string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Structured reports whether the format is meant for programs rather than
+// people.
+func (f Format) Structured() bool {
+	return f != FormatTable
+}
+
+// Note is a single serialized note.
+type Note struct {
+	Notebook string    `json:"notebook"`
+	Index    int       `json:"index"`
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Path     string    `json:"path"`
+	Title    string    `json:"title"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+	Size     int64     `json:"size"`
+	Tags     []string  `json:"tags"`
+	Words    int       `json:"words"`
+}
+
+// NewNote describes f, a note in the named notebook.
+func NewNote(f file.File, notebook string) Note {
+	tags := f.Tags
+	if tags == nil {
+		tags = []string{} // "tags": [] rather than null
+	}
+
+	return Note{
+		Notebook: notebook,
+		Index:    f.Index,
+		ID:       f.ID,
+		Name:     f.RelPath(),
+		Path:     f.FilePath,
+		Title:    f.Title,
+		Created:  f.DateCreated.Truncate(time.Second),
+		Modified: f.DateModified.Truncate(time.Second),
+		Size:     f.Size,
+		Tags:     tags,
+		Words:    f.WordCount,
+	}
+}
+
+// document is the top-level JSON object.
+type document struct {
+	SchemaVersion int    `json:"schema_version"`
+	Notes         []Note `json:"notes"`
+}
+
+// record is a single NDJSON line.
+type record struct {
+	SchemaVersion int `json:"schema_version"`
+	Note
+}
+
+var columns = []string{"notebook", "index", "id", "name", "path", "title", "created", "modified", "size", "tags", "words"}
+
+// Write serializes notes to w in a structured format.
+func Write(w io.Writer, format Format, notes []Note) error {
+	switch format {
+	case FormatJSON:
+		if notes == nil {
+			notes = []Note{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(document{SchemaVersion: SchemaVersion, Notes: notes})
+
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, note := range notes {
+			if err := encoder.Encode(record{SchemaVersion: SchemaVersion, Note: note}); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatCSV:
+		return writeDelimited(w, ',', notes)
+
+	case FormatTSV:
+		return writeDelimited(w, '\t', notes)
+
+	default:
+		return fmt.Errorf("output format %q is not a structured format", format)
+	}
+}
+
+func writeDelimited(w io.Writer, comma rune, notes []Note) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		row := []string{
+			note.Notebook,
+			strconv.Itoa(note.Index),
+			note.ID,
+			note.Name,
+			note.Path,
+			note.Title,
+			note.Created.Format(time.RFC3339),
+			note.Modified.Format(time.RFC3339),
+			strconv.FormatInt(note.Size, 10),
+			strings.Join(note.Tags, " "),
+			strconv.Itoa(note.Words),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
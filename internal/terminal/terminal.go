@@ -0,0 +1,35 @@
+// Package terminal provides small helpers for inspecting the user's terminal.
+package terminal
+
+import "os"
+
+// IsTerminal reports whether the given file is attached to a character device,
+// i.e. an interactive terminal rather than a pipe or regular file.
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// IsPipeOrFile reports whether the given file is a pipe or a regular file,
+// i.e. something that will reach end of file when read, unlike an idle
+// terminal or socket.
+func IsPipeOrFile(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeNamedPipe != 0 || info.Mode().IsRegular()
+}
@@ -0,0 +1,20 @@
+package terminal
+
+import (
+	"os"
+	"strconv"
+)
+
+// Width returns the number of columns of the terminal f is attached to.
+// $COLUMNS takes precedence, as it does for most command-line tools. It
+// returns 0 if the width is unknown, such as when f isn't a terminal.
+func Width(f *os.File) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	if !IsTerminal(f) {
+		return 0
+	}
+	return windowWidth(f)
+}
@@ -0,0 +1,24 @@
+//go:build linux || darwin
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// windowSize mirrors struct winsize from <sys/ioctl.h>.
+type windowSize struct {
+	rows, cols, xPixels, yPixels uint16
+}
+
+// windowWidth asks the terminal driver for the window size.
+func windowWidth(f *os.File) int {
+	var ws windowSize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.cols)
+}
@@ -0,0 +1,150 @@
+// Package textdiff produces line-based unified diffs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op is one line of the edit script turning a into b.
+type op struct {
+	kind opKind
+	line string
+	a, b int // 0-based line numbers in a and b at this point of the script
+}
+
+// Unified returns a unified diff turning a into b, labelled with fromName
+// and toName, with context unchanged lines around each change. It returns
+// an empty string when a and b are identical.
+func Unified(fromName, toName, a, b string, context int) string {
+	ops := editScript(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	for _, hunk := range hunks(ops, context) {
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&sb, hunk)
+	}
+	return sb.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// editScript computes a shortest edit script using the longest common
+// subsequence of lines.
+func editScript(a, b []string) []op {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{kind: opDelete, line: a[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j], a: i, b: j})
+			j++
+		}
+	}
+	return ops
+}
+
+// hunks groups the edit script into runs of changes, each padded with up to
+// context unchanged lines. Changes closer together than 2*context share a hunk.
+func hunks(ops []op, context int) [][]op {
+	var result [][]op
+
+	start, end := -1, -1
+	for idx, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+
+		lo := max(idx-context, 0)
+		if start >= 0 && lo > end {
+			result = append(result, ops[start:end])
+			start = -1
+		}
+		if start < 0 {
+			start = lo
+		}
+		end = min(idx+context+1, len(ops))
+	}
+	if start >= 0 {
+		result = append(result, ops[start:end])
+	}
+	return result
+}
+
+func writeHunk(sb *strings.Builder, hunk []op) {
+	var aCount, bCount int
+	for _, o := range hunk {
+		if o.kind != opInsert {
+			aCount++
+		}
+		if o.kind != opDelete {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(hunk[0].a, aCount), hunkRange(hunk[0].b, bCount))
+
+	for _, o := range hunk {
+		switch o.kind {
+		case opEqual:
+			sb.WriteString(" ")
+		case opDelete:
+			sb.WriteString("-")
+		case opInsert:
+			sb.WriteString("+")
+		}
+		sb.WriteString(o.line)
+		sb.WriteString("\n")
+	}
+}
+
+// hunkRange formats a hunk's start line and length the way diff(1) does.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,146 @@
+// Package timeutil parses the human-friendly durations and dates accepted
+// by note-app's flags.
+package timeutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// ParseDuration parses a duration such as "30d", "2w" or any value accepted
+// by time.ParseDuration ("36h", "90m").
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	units := map[string]time.Duration{"d": Day, "w": Week}
+	for suffix, unit := range units {
+		if !strings.HasSuffix(s, suffix) {
+			continue
+		}
+
+		count, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid duration %q: expected a whole number before %q", s, suffix)
+		}
+		return time.Duration(count) * unit, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q: use forms like 30d, 2w or 12h", s)
+	}
+	return d, nil
+}
+
+// dateLayouts are the absolute date formats accepted by ParseDate.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	time.RFC3339,
+}
+
+// ParseDate parses an absolute date such as "2026-01-02" or
+// "2026-01-02 15:04", interpreting it in the local time zone.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or YYYY-MM-DD HH:MM", s)
+}
+
+// relativeDays are the named days accepted by ParseTime, as days before today.
+var relativeDays = map[string]int{
+	"today":     0,
+	"yesterday": 1,
+}
+
+// relativePeriods are the named periods accepted by ParseTime, as the
+// years, months and days to go back from now.
+var relativePeriods = map[string][3]int{
+	"last-week":  {0, 0, -7},
+	"last-month": {0, -1, 0},
+	"last-year":  {-1, 0, 0},
+}
+
+// ParseTime parses a point in time given as an absolute date (see
+// ParseDate), a duration before now such as "7d" (see ParseDuration), or
+// one of "today", "yesterday", "last-week", "last-month" and "last-year".
+// "today" and "yesterday" mean the start of that day.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	// Keywords and duration units are case-insensitive; dates keep their
+	// case, since layouts such as RFC 3339 need an upper-case 'T'.
+	lower := strings.ToLower(s)
+
+	if days, ok := relativeDays[lower]; ok {
+		return StartOfDay(now).AddDate(0, 0, -days), nil
+	}
+	if period, ok := relativePeriods[lower]; ok {
+		return now.AddDate(period[0], period[1], period[2]), nil
+	}
+
+	if t, err := ParseDate(s); err == nil {
+		return t, nil
+	}
+	if d, err := ParseDuration(lower); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use a date (YYYY-MM-DD), a duration like 7d or 12h, "+
+		"or today, yesterday, last-week, last-month or last-year", s)
+}
+
+// IsWholeDay reports whether s, as accepted by ParseTime, names a whole day
+// rather than a moment: a date without a time of day, "today" or "yesterday".
+func IsWholeDay(s string) bool {
+	s = strings.TrimSpace(s)
+
+	if _, ok := relativeDays[strings.ToLower(s)]; ok {
+		return true
+	}
+	_, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	return err == nil
+}
+
+// StartOfDay returns midnight at the start of t's day, in t's location.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// FormatRelative describes how long before now t was, such as "5m ago" or
+// "3d ago". Times more than about a month old, or in the future, are shown
+// as a date instead.
+func FormatRelative(t, now time.Time) string {
+	elapsed := now.Sub(t)
+
+	switch {
+	case elapsed < 0 || elapsed >= 5*Week:
+		return t.Format("2006-01-02")
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm ago", int(elapsed/time.Minute))
+	case elapsed < Day:
+		return fmt.Sprintf("%dh ago", int(elapsed/time.Hour))
+	case elapsed < Week:
+		return fmt.Sprintf("%dd ago", int(elapsed/Day))
+	default:
+		return fmt.Sprintf("%dw ago", int(elapsed/Week))
+	}
+}
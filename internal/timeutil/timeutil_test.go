@@ -0,0 +1,117 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2026-01-02", want: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)},
+		{input: "  2026-01-02  ", want: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)},
+		{input: "2026-01-02 15:04", want: time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)},
+		{input: "2026-01-02T15:04", want: time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)},
+		{input: "2026-01-02T15:04:05Z", want: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{input: "2026-01-02T15:04:05+02:00", want: time.Date(2026, 1, 2, 13, 4, 5, 0, time.UTC)},
+		{input: "2026-13-02", wantErr: true},
+		{input: "02/01/2026", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) = %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 3, 15, 14, 30, 0, 0, time.Local)
+	startOfToday := time.Date(2026, 3, 15, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		// Dates
+		{input: "2026-01-02", want: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)},
+		{input: "2026-01-02 15:04", want: time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)},
+		{input: "2026-01-02T15:04", want: time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)},
+		{input: "2026-01-02T15:04:05Z", want: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+
+		// Durations before now
+		{input: "7d", want: now.Add(-7 * Day)},
+		{input: "2w", want: now.Add(-2 * Week)},
+		{input: "12h", want: now.Add(-12 * time.Hour)},
+		{input: "90m", want: now.Add(-90 * time.Minute)},
+		{input: "7D", want: now.Add(-7 * Day)},
+		{input: " 12H ", want: now.Add(-12 * time.Hour)},
+
+		// Keywords
+		{input: "today", want: startOfToday},
+		{input: "Today", want: startOfToday},
+		{input: "yesterday", want: startOfToday.AddDate(0, 0, -1)},
+		{input: "last-week", want: now.AddDate(0, 0, -7)},
+		{input: "LAST-MONTH", want: now.AddDate(0, -1, 0)},
+		{input: "last-year", want: now.AddDate(-1, 0, 0)},
+
+		// Invalid
+		{input: "", wantErr: true},
+		{input: "soon", wantErr: true},
+		{input: "-3d", wantErr: true},
+		{input: "2026-01-02t15:04x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTime(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTime(%q) = %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTime(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWholeDay(t *testing.T) {
+	tests := map[string]bool{
+		"2026-01-02":       true,
+		"today":            true,
+		"Yesterday":        true,
+		"2026-01-02 15:04": false,
+		"2026-01-02T15:04": false,
+		"last-week":        false,
+		"7d":               false,
+	}
+
+	for input, want := range tests {
+		if got := IsWholeDay(input); got != want {
+			t.Errorf("IsWholeDay(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
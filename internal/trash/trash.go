@@ -0,0 +1,252 @@
+// Package trash moves deleted notes into a recoverable trash directory.
+//
+// Each trashed note is stored as '<unix-nanos>_<file name>' alongside a
+// '.trashinfo' JSON sidecar recording where it came from and when it was
+// deleted.
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhysmah/note-app/file"
+)
+
+const (
+	infoSuffix = ".trashinfo"
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	trashDirPermissions  = 0755
+	trashInfoPermissions = 0644
+)
+
+// Entry is a single note in the trash.
+type Entry struct {
+	OriginalPath string    `json:"original_path"`
+	DeletedAt    time.Time `json:"deleted_at"`
+
+	storedName string
+}
+
+// Name returns the note's original file name.
+func (e Entry) Name() string {
+	return filepath.Base(e.OriginalPath)
+}
+
+// Bin is a trash directory.
+type Bin struct {
+	dir string
+}
+
+// New returns the trash bin stored in dir. The directory is created the
+// first time a note is moved into it.
+func New(dir string) *Bin {
+	return &Bin{dir: dir}
+}
+
+// Dir returns the directory holding trashed notes.
+func (b *Bin) Dir() string {
+	return b.dir
+}
+
+// Move puts the note at notePath into the trash.
+func (b *Bin) Move(notePath string) (Entry, error) {
+	if err := os.MkdirAll(b.dir, trashDirPermissions); err != nil {
+		return Entry{}, fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	absPath, err := filepath.Abs(notePath)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to resolve note path: %w", err)
+	}
+
+	now := time.Now()
+	entry := Entry{
+		OriginalPath: absPath,
+		DeletedAt:    now,
+		storedName:   strconv.FormatInt(now.UnixNano(), 10) + "_" + filepath.Base(absPath),
+	}
+
+	info, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to encode trash info: %w", err)
+	}
+	if err := os.WriteFile(b.infoPath(entry), info, trashInfoPermissions); err != nil {
+		return Entry{}, fmt.Errorf("failed to write trash info: %w", err)
+	}
+
+	if err := moveFile(absPath, b.notePath(entry)); err != nil {
+		os.Remove(b.infoPath(entry))
+		return Entry{}, fmt.Errorf("failed to move note to trash: %w", err)
+	}
+
+	return entry, nil
+}
+
+// List returns every note in the trash, most recently deleted first.
+func (b *Bin) List() ([]Entry, error) {
+	dirEntries, err := os.ReadDir(b.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read trash directory: %w", err)
+	}
+
+	var entries []Entry
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if !strings.HasSuffix(name, infoSuffix) {
+			continue
+		}
+
+		info, err := os.ReadFile(filepath.Join(b.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trash info %q: %w", name, err)
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(info, &entry); err != nil {
+			return nil, fmt.Errorf("invalid trash info %q: %w", name, err)
+		}
+		entry.storedName = strings.TrimSuffix(name, infoSuffix)
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(a, c int) bool {
+		return entries[a].DeletedAt.After(entries[c].DeletedAt)
+	})
+	return entries, nil
+}
+
+// Restore moves a trashed note back to its original location. It refuses to
+// overwrite a note that has since been created at the same path.
+func (b *Bin) Restore(entry Entry) error {
+	if _, err := os.Stat(entry.OriginalPath); err == nil {
+		return fmt.Errorf("a note already exists at %q", entry.OriginalPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), trashDirPermissions); err != nil {
+		return fmt.Errorf("failed to recreate notes directory: %w", err)
+	}
+
+	if err := moveFile(b.notePath(entry), entry.OriginalPath); err != nil {
+		return fmt.Errorf("failed to restore note: %w", err)
+	}
+
+	if err := os.Remove(b.infoPath(entry)); err != nil {
+		return fmt.Errorf("failed to remove trash info: %w", err)
+	}
+	return nil
+}
+
+// Remove permanently deletes a note from the trash.
+func (b *Bin) Remove(entry Entry) error {
+	if err := os.Remove(b.notePath(entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete %q: %w", entry.Name(), err)
+	}
+	if err := os.Remove(b.infoPath(entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete trash info for %q: %w", entry.Name(), err)
+	}
+	return nil
+}
+
+// Empty permanently deletes notes that were trashed more than olderThan ago.
+// An olderThan of zero empties the whole trash. It returns the removed entries.
+func (b *Bin) Empty(olderThan time.Duration) ([]Entry, error) {
+	entries, err := b.List()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var removed []Entry
+
+	for _, entry := range entries {
+		if olderThan > 0 && entry.DeletedAt.After(cutoff) {
+			continue
+		}
+		if err := b.Remove(entry); err != nil {
+			return removed, err
+		}
+		removed = append(removed, entry)
+	}
+
+	return removed, nil
+}
+
+func (b *Bin) notePath(entry Entry) string {
+	return filepath.Join(b.dir, entry.storedName)
+}
+
+func (b *Bin) infoPath(entry Entry) string {
+	return filepath.Join(b.dir, entry.storedName+infoSuffix)
+}
+
+// moveFile renames src to dst, falling back to copy and delete when the two
+// are on different file systems.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+
+	os.Chtimes(dst, time.Now(), info.ModTime())
+	return os.Remove(src)
+}
+
+// Resolve finds a single trashed note by its original file name, the name it
+// was created with, or its number in List order (as shown by 'trash list').
+// When several trashed notes share a name, the most recently deleted wins.
+func Resolve(entries []Entry, query string) (Entry, error) {
+	query = strings.TrimSpace(query)
+
+	for _, entry := range entries {
+		if entry.Name() == query || file.DisplayName(entry.Name()) == query {
+			return entry, nil
+		}
+	}
+
+	if number, err := strconv.Atoi(strings.TrimPrefix(query, "#")); err == nil {
+		if number < 1 || number > len(entries) {
+			return Entry{}, fmt.Errorf("no trashed note at number %d; the trash holds %d notes", number, len(entries))
+		}
+		return entries[number-1], nil
+	}
+
+	return Entry{}, fmt.Errorf("no trashed note matches %q", query)
+}
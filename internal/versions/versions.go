@@ -0,0 +1,284 @@
+// Package versions keeps content-addressed snapshots of notes so earlier
+// versions can be listed, compared and restored without git.
+//
+// Snapshot content is stored once per distinct SHA-256 digest under
+// 'objects/', and each note's version list is a JSON file under 'notes/'
+// named after the note's ID, so history survives renames.
+package versions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	objectsDirName = "objects"
+	notesDirName   = "notes"
+	logSuffix      = ".json"
+
+	// MinHashPrefixLength is the shortest hash prefix accepted by Resolve.
+	MinHashPrefixLength = 4
+
+	// Octal: 4 = read, 2 = write, 1 = execute
+	versionsDirPermissions  = 0755
+	versionsFilePermissions = 0644
+)
+
+// Version is a single snapshot of a note. Numbers start at 1 and are never
+// reused, so they stay stable when old snapshots are pruned.
+type Version struct {
+	Number  int       `json:"number"`
+	Hash    string    `json:"hash"`
+	SavedAt time.Time `json:"saved_at"`
+	Name    string    `json:"name"` // the note's file name when the snapshot was taken
+	Size    int64     `json:"size"`
+}
+
+// ShortHash returns the abbreviated content hash shown to users.
+func (v Version) ShortHash() string {
+	if len(v.Hash) <= 8 {
+		return v.Hash
+	}
+	return v.Hash[:8]
+}
+
+// Store is a versions directory.
+type Store struct {
+	dir string
+}
+
+// New returns the snapshot store in dir. The directory is created the first
+// time a snapshot is taken.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Snapshot records content as the newest version of the note with noteID.
+// Nothing is recorded if content is identical to the newest version; the
+// returned bool reports whether a new version was created.
+func (s *Store) Snapshot(noteID, name string, content []byte) (Version, bool, error) {
+	if noteID == "" {
+		return Version{}, false, errors.New("cannot snapshot a note without an ID")
+	}
+
+	versions, err := s.List(noteID)
+	if err != nil {
+		return Version{}, false, err
+	}
+
+	digest := sha256.Sum256(content)
+	hash := hex.EncodeToString(digest[:])
+
+	if len(versions) > 0 && versions[len(versions)-1].Hash == hash {
+		return versions[len(versions)-1], false, nil
+	}
+
+	if err := s.writeObject(hash, content); err != nil {
+		return Version{}, false, err
+	}
+
+	version := Version{
+		Number:  1,
+		Hash:    hash,
+		SavedAt: time.Now(),
+		Name:    name,
+		Size:    int64(len(content)),
+	}
+	if len(versions) > 0 {
+		version.Number = versions[len(versions)-1].Number + 1
+	}
+
+	if err := s.writeLog(noteID, append(versions, version)); err != nil {
+		return Version{}, false, err
+	}
+	return version, true, nil
+}
+
+// List returns every version of the note with noteID, oldest first.
+func (s *Store) List(noteID string) ([]Version, error) {
+	data, err := os.ReadFile(s.logPath(noteID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read versions: %w", err)
+	}
+
+	var versions []Version
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("invalid versions file %q: %w", s.logPath(noteID), err)
+	}
+	return versions, nil
+}
+
+// Content returns the snapshot content of version.
+func (s *Store) Content(version Version) ([]byte, error) {
+	content, err := os.ReadFile(s.objectPath(version.Hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version %d: %w", version.Number, err)
+	}
+	return content, nil
+}
+
+// Prune keeps only the newest keep versions of the note with noteID, then
+// deletes any snapshot content no note refers to any more. It returns how
+// many versions were dropped.
+func (s *Store) Prune(noteID string, keep int) (int, error) {
+	versions, err := s.List(noteID)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) <= keep {
+		return 0, nil
+	}
+
+	dropped := len(versions) - keep
+	if err := s.writeLog(noteID, versions[dropped:]); err != nil {
+		return 0, err
+	}
+
+	if err := s.removeUnreferencedObjects(); err != nil {
+		return dropped, err
+	}
+	return dropped, nil
+}
+
+// removeUnreferencedObjects deletes snapshot content that no version log
+// points at.
+func (s *Store) removeUnreferencedObjects() error {
+	logs, err := os.ReadDir(filepath.Join(s.dir, notesDirName))
+	if err != nil {
+		return fmt.Errorf("failed to read versions directory: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+	for _, log := range logs {
+		noteID, ok := strings.CutSuffix(log.Name(), logSuffix)
+		if !ok {
+			continue
+		}
+		versions, err := s.List(noteID)
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			referenced[version.Hash] = true
+		}
+	}
+
+	objects, err := os.ReadDir(filepath.Join(s.dir, objectsDirName))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+	for _, object := range objects {
+		if referenced[object.Name()] {
+			continue
+		}
+		if err := os.Remove(s.objectPath(object.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove unused snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) writeObject(hash string, content []byte) error {
+	objectPath := s.objectPath(hash)
+	if _, err := os.Stat(objectPath); err == nil {
+		return nil // identical content is already stored
+	}
+
+	if err := os.MkdirAll(filepath.Dir(objectPath), versionsDirPermissions); err != nil {
+		return fmt.Errorf("failed to create versions directory: %w", err)
+	}
+	return writeFileAtomic(objectPath, content)
+}
+
+func (s *Store) writeLog(noteID string, versions []Version) error {
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode versions: %w", err)
+	}
+
+	logPath := s.logPath(noteID)
+	if err := os.MkdirAll(filepath.Dir(logPath), versionsDirPermissions); err != nil {
+		return fmt.Errorf("failed to create versions directory: %w", err)
+	}
+	return writeFileAtomic(logPath, data)
+}
+
+func (s *Store) objectPath(hash string) string {
+	return filepath.Join(s.dir, objectsDirName, hash)
+}
+
+func (s *Store) logPath(noteID string) string {
+	return filepath.Join(s.dir, notesDirName, noteID+logSuffix)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place
+// so an interrupted write never leaves a truncated file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), versionsFilePermissions); err != nil {
+		return fmt.Errorf("failed to set permissions on %q: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save %q: %w", path, err)
+	}
+	return nil
+}
+
+// Resolve finds a single version by its number ('3' or 'v3') or by a unique
+// prefix of its content hash.
+func Resolve(versions []Version, query string) (Version, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	if number, err := strconv.Atoi(strings.TrimPrefix(query, "v")); err == nil {
+		for _, version := range versions {
+			if version.Number == number {
+				return version, nil
+			}
+		}
+		return Version{}, fmt.Errorf("no version %d; see 'note-app versions'", number)
+	}
+
+	if len(query) < MinHashPrefixLength {
+		return Version{}, fmt.Errorf("version %q must be a number or at least %d characters of a hash", query, MinHashPrefixLength)
+	}
+
+	var matches []Version
+	for _, version := range versions {
+		if strings.HasPrefix(version.Hash, query) {
+			matches = append(matches, version)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Version{}, fmt.Errorf("no version matches %q; see 'note-app versions'", query)
+	case 1:
+		return matches[0], nil
+	default:
+		// The same content can be saved more than once; pick the newest.
+		return matches[len(matches)-1], nil
+	}
+}
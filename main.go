@@ -1,10 +1,27 @@
 package main
 
 import (
+	_ "github.com/rhysmah/note-app/cmd/cache"
+	_ "github.com/rhysmah/note-app/cmd/config"
 	_ "github.com/rhysmah/note-app/cmd/delete"
+	_ "github.com/rhysmah/note-app/cmd/diff"
+	_ "github.com/rhysmah/note-app/cmd/edit"
+	_ "github.com/rhysmah/note-app/cmd/history"
+	_ "github.com/rhysmah/note-app/cmd/index"
 	_ "github.com/rhysmah/note-app/cmd/list"
+	_ "github.com/rhysmah/note-app/cmd/move"
 	_ "github.com/rhysmah/note-app/cmd/new"
+	_ "github.com/rhysmah/note-app/cmd/notebook"
+	_ "github.com/rhysmah/note-app/cmd/rename"
+	_ "github.com/rhysmah/note-app/cmd/restore"
+	_ "github.com/rhysmah/note-app/cmd/restoreversion"
+	_ "github.com/rhysmah/note-app/cmd/revert"
 	"github.com/rhysmah/note-app/cmd/root"
+	_ "github.com/rhysmah/note-app/cmd/search"
+	_ "github.com/rhysmah/note-app/cmd/tag"
+	_ "github.com/rhysmah/note-app/cmd/trash"
+	_ "github.com/rhysmah/note-app/cmd/versions"
+	_ "github.com/rhysmah/note-app/cmd/view"
 )
 
 func main() {